@@ -67,3 +67,41 @@ func WrapConsumer(c sarama.Consumer, opts ...Option) sarama.Consumer {
 		opts:     opts,
 	}
 }
+
+type partitionOffsetManager struct {
+	sarama.PartitionOffsetManager
+	dispatcher *consumerMessagesDispatcherWrapper
+}
+
+func (pom *partitionOffsetManager) MarkOffset(offset int64, metadata string) {
+	pom.PartitionOffsetManager.MarkOffset(offset, metadata)
+	pom.dispatcher.markOffset(offset)
+}
+
+func (pom *partitionOffsetManager) ResetOffset(offset int64, metadata string) {
+	pom.PartitionOffsetManager.ResetOffset(offset, metadata)
+	pom.dispatcher.resetOffset(offset)
+}
+
+// WrapPartitionOffsetManager wraps a sarama.PartitionOffsetManager managing
+// the offsets of the partition consumed by pc, so that the offsets it marks
+// are recorded as the committed offsets of the lag of pc. pc must be returned
+// by WrapPartitionConsumer or by a Consumer returned by WrapConsumer,
+// otherwise pom is returned as is.
+func WrapPartitionOffsetManager(pom sarama.PartitionOffsetManager, pc sarama.PartitionConsumer) sarama.PartitionOffsetManager {
+	wrapped, ok := pc.(*partitionConsumer)
+	if !ok {
+		return pom
+	}
+	dispatcher, ok := wrapped.dispatcher.(*consumerMessagesDispatcherWrapper)
+	if !ok {
+		return pom
+	}
+	if offset, _ := pom.NextOffset(); offset >= 0 {
+		dispatcher.resetOffset(offset)
+	}
+	return &partitionOffsetManager{
+		PartitionOffsetManager: pom,
+		dispatcher:             dispatcher,
+	}
+}
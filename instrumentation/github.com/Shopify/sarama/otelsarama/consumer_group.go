@@ -15,6 +15,8 @@
 package otelsarama // import "go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
 
 import (
+	"sync"
+
 	"github.com/Shopify/sarama"
 )
 
@@ -22,6 +24,16 @@ type consumerGroupHandler struct {
 	sarama.ConsumerGroupHandler
 
 	cfg config
+
+	mu sync.Mutex
+	// dispatchers are the dispatchers of the claims being consumed, by
+	// topic and partition.
+	dispatchers map[topicPartition]*consumerMessagesDispatcherWrapper
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
 }
 
 // ConsumeClaim wraps the session and claim to add instruments for messages.
@@ -29,15 +41,47 @@ type consumerGroupHandler struct {
 func (h *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	// Wrap claim
 	dispatcher := newConsumerMessagesDispatcherWrapper(claim, h.cfg)
+	dispatcher.setPartition(claim.Topic(), claim.Partition())
+	// The claim starts at the committed offset, unless the group has
+	// none for the partition.
+	if offset := claim.InitialOffset(); offset >= 0 {
+		dispatcher.resetOffset(offset)
+	}
 	go dispatcher.Run()
 	claim = &consumerGroupClaim{
 		ConsumerGroupClaim: claim,
 		dispatcher:         dispatcher,
 	}
 
+	tp := topicPartition{topic: claim.Topic(), partition: claim.Partition()}
+	h.mu.Lock()
+	h.dispatchers[tp] = dispatcher
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		if h.dispatchers[tp] == dispatcher {
+			delete(h.dispatchers, tp)
+		}
+		h.mu.Unlock()
+	}()
+
+	// Wrap session
+	session = &consumerGroupSession{
+		ConsumerGroupSession: session,
+		handler:              h,
+	}
+
 	return h.ConsumerGroupHandler.ConsumeClaim(session, claim)
 }
 
+// dispatcher returns the dispatcher of the claim of partition of topic, or
+// nil if it is not being consumed.
+func (h *consumerGroupHandler) dispatcher(topic string, partition int32) *consumerMessagesDispatcherWrapper {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.dispatchers[topicPartition{topic: topic, partition: partition}]
+}
+
 // WrapConsumerGroupHandler wraps a sarama.ConsumerGroupHandler causing each received
 // message to be traced.
 func WrapConsumerGroupHandler(handler sarama.ConsumerGroupHandler, opts ...Option) sarama.ConsumerGroupHandler {
@@ -46,6 +90,7 @@ func WrapConsumerGroupHandler(handler sarama.ConsumerGroupHandler, opts ...Optio
 	return &consumerGroupHandler{
 		ConsumerGroupHandler: handler,
 		cfg:                  cfg,
+		dispatchers:          make(map[topicPartition]*consumerMessagesDispatcherWrapper),
 	}
 }
 
@@ -57,3 +102,33 @@ type consumerGroupClaim struct {
 func (c *consumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage {
 	return c.dispatcher.Messages()
 }
+
+// consumerGroupSession records the offsets marked in the session as the
+// committed offsets of the claims consumed. Sarama commits the marked offsets
+// either automatically or when Commit is called.
+type consumerGroupSession struct {
+	sarama.ConsumerGroupSession
+	handler *consumerGroupHandler
+}
+
+func (s *consumerGroupSession) MarkOffset(topic string, partition int32, offset int64, metadata string) {
+	s.ConsumerGroupSession.MarkOffset(topic, partition, offset, metadata)
+	if d := s.handler.dispatcher(topic, partition); d != nil {
+		d.markOffset(offset)
+	}
+}
+
+func (s *consumerGroupSession) ResetOffset(topic string, partition int32, offset int64, metadata string) {
+	s.ConsumerGroupSession.ResetOffset(topic, partition, offset, metadata)
+	if d := s.handler.dispatcher(topic, partition); d != nil {
+		d.resetOffset(offset)
+	}
+}
+
+func (s *consumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.ConsumerGroupSession.MarkMessage(msg, metadata)
+	if d := s.handler.dispatcher(msg.Topic, msg.Partition); d != nil {
+		// The offset following the message is committed.
+		d.markOffset(msg.Offset + 1)
+	}
+}
@@ -18,10 +18,13 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/Shopify/sarama"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -30,21 +33,78 @@ type consumerMessagesDispatcher interface {
 	Messages() <-chan *sarama.ConsumerMessage
 }
 
+// consumerMessagesSource is a consumerMessagesDispatcher that knows the high
+// water mark offset of the partition it consumes, e.g. a
+// sarama.PartitionConsumer or a sarama.ConsumerGroupClaim.
+type consumerMessagesSource interface {
+	consumerMessagesDispatcher
+	HighWaterMarkOffset() int64
+}
+
 type consumerMessagesDispatcherWrapper struct {
-	d        consumerMessagesDispatcher
+	d        consumerMessagesSource
 	messages chan *sarama.ConsumerMessage
 
-	cfg config
+	cfg         config
+	instruments instruments
+
+	mu sync.Mutex
+	// topic and partition are the consumed partition, known once claimed
+	// or once its first message is received.
+	topic     string
+	partition int32
+	known     bool
+	// committed is the committed offset of the partition, that is the
+	// offset of the next message to consume, or -1 until it is known.
+	committed int64
+
+	// lagRegistration observes the lag until the dispatcher stops, nil if
+	// it failed to be registered.
+	lagRegistration metric.Registration
+}
+
+func newConsumerMessagesDispatcherWrapper(d consumerMessagesSource, cfg config) *consumerMessagesDispatcherWrapper {
+	w := &consumerMessagesDispatcherWrapper{
+		d:           d,
+		messages:    make(chan *sarama.ConsumerMessage),
+		cfg:         cfg,
+		instruments: newInstruments(cfg.Meter),
+		committed:   -1,
+	}
+	reg, err := cfg.Meter.RegisterCallback(w.observeLag, w.instruments.consumerLag)
+	if err != nil {
+		otel.Handle(err)
+	} else {
+		w.lagRegistration = reg
+	}
+	return w
+}
+
+// setPartition sets the partition consumed by w.
+func (w *consumerMessagesDispatcherWrapper) setPartition(topic string, partition int32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.topic, w.partition, w.known = topic, partition, true
 }
 
-func newConsumerMessagesDispatcherWrapper(d consumerMessagesDispatcher, cfg config) *consumerMessagesDispatcherWrapper {
-	return &consumerMessagesDispatcherWrapper{
-		d:        d,
-		messages: make(chan *sarama.ConsumerMessage),
-		cfg:      cfg,
+// markOffset sets the committed offset of the partition consumed by w to
+// offset if it is greater, as sarama does when an offset is marked.
+func (w *consumerMessagesDispatcherWrapper) markOffset(offset int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if offset > w.committed {
+		w.committed = offset
 	}
 }
 
+// resetOffset sets the committed offset of the partition consumed by w to
+// offset.
+func (w *consumerMessagesDispatcherWrapper) resetOffset(offset int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.committed = offset
+}
+
 // Messages returns the read channel for the messages that are returned by
 // the broker.
 func (w *consumerMessagesDispatcherWrapper) Messages() <-chan *sarama.ConsumerMessage {
@@ -52,6 +112,14 @@ func (w *consumerMessagesDispatcherWrapper) Messages() <-chan *sarama.ConsumerMe
 }
 
 func (w *consumerMessagesDispatcherWrapper) Run() {
+	if w.lagRegistration != nil {
+		defer func() {
+			if err := w.lagRegistration.Unregister(); err != nil {
+				otel.Handle(err)
+			}
+		}()
+	}
+
 	msgs := w.d.Messages()
 
 	for msg := range msgs {
@@ -60,14 +128,7 @@ func (w *consumerMessagesDispatcherWrapper) Run() {
 		parentSpanContext := w.cfg.Propagators.Extract(context.Background(), carrier)
 
 		// Create a span.
-		attrs := []attribute.KeyValue{
-			semconv.MessagingSystem("kafka"),
-			semconv.MessagingDestinationKindTopic,
-			semconv.MessagingDestinationName(msg.Topic),
-			semconv.MessagingOperationReceive,
-			semconv.MessagingMessageID(strconv.FormatInt(msg.Offset, 10)),
-			semconv.MessagingKafkaSourcePartition(int(msg.Partition)),
-		}
+		attrs := append(consumerMessageAttrs(msg), semconv.MessagingOperationReceive)
 		opts := []trace.SpanStartOption{
 			trace.WithAttributes(attrs...),
 			trace.WithSpanKind(trace.SpanKindConsumer),
@@ -77,6 +138,14 @@ func (w *consumerMessagesDispatcherWrapper) Run() {
 		// Inject current span context, so consumers can use it to propagate span.
 		w.cfg.Propagators.Inject(newCtx, carrier)
 
+		w.instruments.receiveMessages.Add(newCtx, 1, metric.WithAttributes(
+			semconv.MessagingSystem("kafka"),
+			semconv.MessagingDestinationName(msg.Topic),
+			semconv.MessagingKafkaSourcePartition(int(msg.Partition)),
+		))
+
+		w.setPartition(msg.Topic, msg.Partition)
+
 		// Send messages back to user.
 		w.messages <- msg
 
@@ -84,3 +153,38 @@ func (w *consumerMessagesDispatcherWrapper) Run() {
 	}
 	close(w.messages)
 }
+
+// observeLag observes the lag of the consumed partition: the difference
+// between its high water mark offset and its committed offset. Nothing is
+// observed until the committed offset is known.
+func (w *consumerMessagesDispatcherWrapper) observeLag(_ context.Context, o metric.Observer) error {
+	w.mu.Lock()
+	topic, partition, known, committed := w.topic, w.partition, w.known, w.committed
+	w.mu.Unlock()
+	if !known || committed < 0 {
+		return nil
+	}
+
+	lag := w.d.HighWaterMarkOffset() - committed
+	if lag < 0 {
+		lag = 0
+	}
+	o.ObserveInt64(w.instruments.consumerLag, lag, metric.WithAttributes(
+		semconv.MessagingSystem("kafka"),
+		semconv.MessagingDestinationName(topic),
+		semconv.MessagingKafkaSourcePartition(int(partition)),
+	))
+	return nil
+}
+
+// consumerMessageAttrs returns the attributes describing msg of the spans
+// created for it by the consumer.
+func consumerMessageAttrs(msg *sarama.ConsumerMessage) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.MessagingSystem("kafka"),
+		semconv.MessagingDestinationKindTopic,
+		semconv.MessagingDestinationName(msg.Topic),
+		semconv.MessagingMessageID(strconv.FormatInt(msg.Offset, 10)),
+		semconv.MessagingKafkaSourcePartition(int(msg.Partition)),
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsarama // import "go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Names of the instruments recorded by the producers and consumers wrapped
+// by this package.
+const (
+	PublishDuration = "messaging.publish.duration"   // Outgoing message publish duration
+	PublishMessages = "messaging.publish.messages"   // Outgoing messages published
+	PublishBytes    = "messaging.publish.bytes"      // Outgoing message payload size
+	PublishErrors   = "messaging.publish.errors"     // Outgoing messages failed to be published
+	ReceiveMessages = "messaging.receive.messages"   // Incoming messages received
+	ConsumerLag     = "messaging.kafka.consumer.lag" // Messages of a partition after its committed offset
+)
+
+// instruments are the instruments of a wrapped producer or consumer.
+type instruments struct {
+	publishDuration metric.Float64Histogram
+	publishMessages metric.Int64Counter
+	publishBytes    metric.Int64Counter
+	publishErrors   metric.Int64Counter
+	receiveMessages metric.Int64Counter
+	consumerLag     metric.Int64ObservableGauge
+}
+
+func newInstruments(meter metric.Meter) instruments {
+	var (
+		i   instruments
+		err error
+	)
+
+	i.publishDuration, err = meter.Float64Histogram(
+		PublishDuration,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of publishing a message, until it is acknowledged by the broker."),
+	)
+	handleErr(err)
+
+	i.publishMessages, err = meter.Int64Counter(
+		PublishMessages,
+		metric.WithUnit("{message}"),
+		metric.WithDescription("Counts the messages published."),
+	)
+	handleErr(err)
+
+	i.publishBytes, err = meter.Int64Counter(
+		PublishBytes,
+		metric.WithUnit("By"),
+		metric.WithDescription("Measures the estimated size of the messages published."),
+	)
+	handleErr(err)
+
+	i.publishErrors, err = meter.Int64Counter(
+		PublishErrors,
+		metric.WithUnit("{message}"),
+		metric.WithDescription("Counts the messages that failed to be published."),
+	)
+	handleErr(err)
+
+	i.receiveMessages, err = meter.Int64Counter(
+		ReceiveMessages,
+		metric.WithUnit("{message}"),
+		metric.WithDescription("Counts the messages received."),
+	)
+	handleErr(err)
+
+	i.consumerLag, err = meter.Int64ObservableGauge(
+		ConsumerLag,
+		metric.WithUnit("{message}"),
+		metric.WithDescription("Measures the number of messages of a partition after its committed offset."),
+	)
+	handleErr(err)
+
+	return i
+}
+
+func handleErr(err error) {
+	if err != nil {
+		otel.Handle(err)
+	}
+}
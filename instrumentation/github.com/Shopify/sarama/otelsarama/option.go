@@ -16,6 +16,7 @@ package otelsarama // import "go.opentelemetry.io/contrib/instrumentation/github
 
 import (
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -24,9 +25,11 @@ const defaultTracerName = "go.opentelemetry.io/contrib/instrumentation/github.co
 
 type config struct {
 	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
 	Propagators    propagation.TextMapPropagator
 
 	Tracer trace.Tracer
+	Meter  metric.Meter
 }
 
 // newConfig returns a config with all Options set.
@@ -34,6 +37,7 @@ func newConfig(opts ...Option) config {
 	cfg := config{
 		Propagators:    otel.GetTextMapPropagator(),
 		TracerProvider: otel.GetTracerProvider(),
+		MeterProvider:  otel.GetMeterProvider(),
 	}
 	for _, opt := range opts {
 		opt.apply(&cfg)
@@ -43,6 +47,10 @@ func newConfig(opts ...Option) config {
 		defaultTracerName,
 		trace.WithInstrumentationVersion(Version()),
 	)
+	cfg.Meter = cfg.MeterProvider.Meter(
+		defaultTracerName,
+		metric.WithInstrumentationVersion(Version()),
+	)
 
 	return cfg
 }
@@ -68,6 +76,16 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithPropagators specifies propagators to use for extracting
 // information from the HTTP requests. If none are specified, global
 // ones will be used.
@@ -21,6 +21,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -45,6 +47,7 @@ func (fakeTracer) Start(ctx context.Context, spanName string, opts ...trace.Span
 
 func TestNewConfig(t *testing.T) {
 	tp := fakeTracerProvider{}
+	mp := noop.NewMeterProvider()
 	prop := propagation.NewCompositeTextMapPropagator()
 
 	testCases := []struct {
@@ -61,6 +64,8 @@ func TestNewConfig(t *testing.T) {
 				TracerProvider: tp,
 				Tracer:         tp.Tracer(defaultTracerName, trace.WithInstrumentationVersion(Version())),
 				Propagators:    otel.GetTextMapPropagator(),
+				MeterProvider:  otel.GetMeterProvider(),
+				Meter:          otel.GetMeterProvider().Meter(defaultTracerName, metric.WithInstrumentationVersion(Version())),
 			},
 		},
 		{
@@ -72,6 +77,34 @@ func TestNewConfig(t *testing.T) {
 				TracerProvider: otel.GetTracerProvider(),
 				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(Version())),
 				Propagators:    otel.GetTextMapPropagator(),
+				MeterProvider:  otel.GetMeterProvider(),
+				Meter:          otel.GetMeterProvider().Meter(defaultTracerName, metric.WithInstrumentationVersion(Version())),
+			},
+		},
+		{
+			name: "with meter provider",
+			opts: []Option{
+				WithMeterProvider(mp),
+			},
+			expected: config{
+				TracerProvider: otel.GetTracerProvider(),
+				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(Version())),
+				Propagators:    otel.GetTextMapPropagator(),
+				MeterProvider:  mp,
+				Meter:          mp.Meter(defaultTracerName, metric.WithInstrumentationVersion(Version())),
+			},
+		},
+		{
+			name: "with empty meter provider",
+			opts: []Option{
+				WithMeterProvider(nil),
+			},
+			expected: config{
+				TracerProvider: otel.GetTracerProvider(),
+				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(Version())),
+				Propagators:    otel.GetTextMapPropagator(),
+				MeterProvider:  otel.GetMeterProvider(),
+				Meter:          otel.GetMeterProvider().Meter(defaultTracerName, metric.WithInstrumentationVersion(Version())),
 			},
 		},
 		{
@@ -83,6 +116,8 @@ func TestNewConfig(t *testing.T) {
 				TracerProvider: otel.GetTracerProvider(),
 				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(Version())),
 				Propagators:    prop,
+				MeterProvider:  otel.GetMeterProvider(),
+				Meter:          otel.GetMeterProvider().Meter(defaultTracerName, metric.WithInstrumentationVersion(Version())),
 			},
 		},
 		{
@@ -94,6 +129,8 @@ func TestNewConfig(t *testing.T) {
 				TracerProvider: otel.GetTracerProvider(),
 				Tracer:         otel.GetTracerProvider().Tracer(defaultTracerName, trace.WithInstrumentationVersion(Version())),
 				Propagators:    otel.GetTextMapPropagator(),
+				MeterProvider:  otel.GetMeterProvider(),
+				Meter:          otel.GetMeterProvider().Meter(defaultTracerName, metric.WithInstrumentationVersion(Version())),
 			},
 		},
 	}
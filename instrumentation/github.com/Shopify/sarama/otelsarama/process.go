@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelsarama // import "go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OffsetMarkedKey is the attribute key of the process spans of ConsumeClaim
+// recording whether the offset of the processed message was marked as
+// consumed.
+const OffsetMarkedKey = attribute.Key("messaging.kafka.message.offset_marked")
+
+// StartProcessSpan starts a "process" span for msg as a child of the span
+// context propagated in its headers and returns it along with a copy of ctx
+// holding it. The span covers the processing of msg by the caller, who must
+// end it once done.
+//
+// If msg is received from a consumer wrapped by this package, the span is a
+// child of the "receive" span of msg, which is itself a child of the span of
+// the producer.
+func StartProcessSpan(ctx context.Context, msg *sarama.ConsumerMessage, opts ...Option) (context.Context, trace.Span) {
+	return newConfig(opts...).startProcessSpan(ctx, msg)
+}
+
+func (cfg config) startProcessSpan(ctx context.Context, msg *sarama.ConsumerMessage) (context.Context, trace.Span) {
+	ctx = cfg.Propagators.Extract(ctx, NewConsumerMessageCarrier(msg))
+
+	attrs := append(consumerMessageAttrs(msg), semconv.MessagingOperationProcess)
+	return cfg.Tracer.Start(ctx, fmt.Sprintf("%s process", msg.Topic),
+		trace.WithAttributes(attrs...),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+	)
+}
+
+// ConsumeClaim consumes the messages of claim, processing each of them with
+// handler within a "process" span started by StartProcessSpan. It is intended
+// to be called from the ConsumeClaim method of a sarama.ConsumerGroupHandler.
+//
+// If handler returns nil, the message is marked as consumed in session.
+// Otherwise the error is recorded on the span, which is set to error, and the
+// message is not marked. In both cases, the outcome is recorded on the span
+// with the OffsetMarkedKey attribute and the consumption continues with the
+// next message.
+//
+// ConsumeClaim returns once the messages channel of claim is closed or the
+// context of session is done.
+func ConsumeClaim(
+	session sarama.ConsumerGroupSession,
+	claim sarama.ConsumerGroupClaim,
+	handler func(context.Context, *sarama.ConsumerMessage) error,
+	opts ...Option,
+) error {
+	cfg := newConfig(opts...)
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			ctx, span := cfg.startProcessSpan(session.Context(), msg)
+			if err := handler(ctx, msg); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				span.SetAttributes(OffsetMarkedKey.Bool(false))
+			} else {
+				session.MarkMessage(msg, "")
+				span.SetAttributes(OffsetMarkedKey.Bool(true))
+			}
+			span.End()
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
@@ -17,15 +17,18 @@ package otelsarama // import "go.opentelemetry.io/contrib/instrumentation/github
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 
 	"go.opentelemetry.io/otel/codes"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -34,31 +37,104 @@ type syncProducer struct {
 	sarama.SyncProducer
 	cfg          config
 	saramaConfig *sarama.Config
+	instruments  instruments
 }
 
 // SendMessage calls sarama.SyncProducer.SendMessage and traces the request.
 func (p *syncProducer) SendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error) {
-	span := startProducerSpan(p.cfg, p.saramaConfig.Version, msg)
+	mc := startProducerSpan(p.cfg, p.saramaConfig.Version, msg)
 	partition, offset, err = p.SyncProducer.SendMessage(msg)
-	finishProducerSpan(span, partition, offset, err)
+	p.instruments.finishProducerSpan(mc, msg, partition, offset, err)
 	return partition, offset, err
 }
 
 // SendMessages calls sarama.SyncProducer.SendMessages and traces the requests.
+//
+// The call is traced with a batch span linked to the spans of every message.
+// If it fails with sarama.ProducerErrors, only the spans of the messages that
+// failed are set to error.
 func (p *syncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
 	// Although there's only one call made to the SyncProducer, the messages are
 	// treated individually, so we create a span for each one
-	spans := make([]trace.Span, len(msgs))
+	mcs := make([]producerMessageContext, len(msgs))
+	links := make([]trace.Link, len(msgs))
 	for i, msg := range msgs {
-		spans[i] = startProducerSpan(p.cfg, p.saramaConfig.Version, msg)
+		mcs[i] = startProducerSpan(p.cfg, p.saramaConfig.Version, msg)
+		links[i] = trace.Link{SpanContext: mcs[i].span.SpanContext()}
 	}
+	batch := startBatchSpan(p.cfg, msgs, links)
+
 	err := p.SyncProducer.SendMessages(msgs)
-	for i, span := range spans {
-		finishProducerSpan(span, msgs[i].Partition, msgs[i].Offset, err)
+
+	msgErrs, partial := messageErrors(err)
+	for i, mc := range mcs {
+		msgErr := err
+		if partial {
+			msgErr = msgErrs[msgs[i]]
+		}
+		p.instruments.finishProducerSpan(mc, msgs[i], msgs[i].Partition, msgs[i].Offset, msgErr)
+	}
+	if err != nil {
+		batch.SetStatus(codes.Error, err.Error())
 	}
+	batch.End()
 	return err
 }
 
+// startBatchSpan starts the span of a SendMessages call publishing msgs. It
+// is named after the topic of msgs if they all share the same one.
+func startBatchSpan(cfg config, msgs []*sarama.ProducerMessage, links []trace.Link) trace.Span {
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystem("kafka"),
+		semconv.MessagingOperationPublish,
+		semconv.MessagingBatchMessageCount(len(msgs)),
+	}
+	name := "publish"
+	if topic, ok := commonTopic(msgs); ok {
+		name = fmt.Sprintf("%s publish", topic)
+		attrs = append(attrs,
+			semconv.MessagingDestinationKindTopic,
+			semconv.MessagingDestinationName(topic),
+		)
+	}
+	_, span := cfg.Tracer.Start(context.Background(), name,
+		trace.WithAttributes(attrs...),
+		trace.WithLinks(links...),
+		trace.WithSpanKind(trace.SpanKindProducer),
+	)
+	return span
+}
+
+// commonTopic returns the topic of msgs and true if they all share the same
+// one.
+func commonTopic(msgs []*sarama.ProducerMessage) (string, bool) {
+	if len(msgs) == 0 {
+		return "", false
+	}
+	topic := msgs[0].Topic
+	for _, msg := range msgs[1:] {
+		if msg.Topic != topic {
+			return "", false
+		}
+	}
+	return topic, true
+}
+
+// messageErrors returns the error of every message that failed to be
+// published and true if err is a sarama.ProducerErrors. Otherwise, err
+// applies to all the messages and false is returned.
+func messageErrors(err error) (map[*sarama.ProducerMessage]error, bool) {
+	var pErrs sarama.ProducerErrors
+	if !errors.As(err, &pErrs) {
+		return nil, false
+	}
+	errs := make(map[*sarama.ProducerMessage]error, len(pErrs))
+	for _, pErr := range pErrs {
+		errs[pErr.Msg] = pErr.Err
+	}
+	return errs, true
+}
+
 // WrapSyncProducer wraps a sarama.SyncProducer so that all produced messages
 // are traced.
 func WrapSyncProducer(saramaConfig *sarama.Config, producer sarama.SyncProducer, opts ...Option) sarama.SyncProducer {
@@ -71,6 +147,7 @@ func WrapSyncProducer(saramaConfig *sarama.Config, producer sarama.SyncProducer,
 		SyncProducer: producer,
 		cfg:          cfg,
 		saramaConfig: saramaConfig,
+		instruments:  newInstruments(cfg.Meter),
 	}
 }
 
@@ -118,6 +195,8 @@ func (p *asyncProducer) Close() error {
 
 type producerMessageContext struct {
 	span           trace.Span
+	start          time.Time
+	size           int
 	metadataBackup interface{}
 }
 
@@ -126,9 +205,10 @@ type producerMessageContext struct {
 // or not successes will be returned.
 //
 // If `Return.Successes` is false, there is no way to know partition and offset of
-// the message.
+// the message, nor when it is acknowledged, and no publish metrics are recorded.
 func WrapAsyncProducer(saramaConfig *sarama.Config, p sarama.AsyncProducer, opts ...Option) sarama.AsyncProducer {
 	cfg := newConfig(opts...)
+	inst := newInstruments(cfg.Meter)
 	if saramaConfig == nil {
 		saramaConfig = sarama.NewConfig()
 	}
@@ -162,16 +242,12 @@ func WrapAsyncProducer(saramaConfig *sarama.Config, p sarama.AsyncProducer, opts
 				if !ok {
 					continue // wait for closeAsyncSig
 				}
-				span := startProducerSpan(cfg, saramaConfig.Version, msg)
-
 				// Create message context, backend message metadata
-				mc := producerMessageContext{
-					metadataBackup: msg.Metadata,
-					span:           span,
-				}
+				mc := startProducerSpan(cfg, saramaConfig.Version, msg)
+				mc.metadataBackup = msg.Metadata
 
 				// Remember metadata using span ID as a cache key
-				msg.Metadata = span.SpanContext().SpanID()
+				msg.Metadata = mc.span.SpanContext().SpanID()
 				if saramaConfig.Producer.Return.Successes {
 					mtx.Lock()
 					producerMessageContexts[msg.Metadata] = mc
@@ -204,7 +280,7 @@ func WrapAsyncProducer(saramaConfig *sarama.Config, p sarama.AsyncProducer, opts
 			mtx.Lock()
 			if mc, ok := producerMessageContexts[key]; ok {
 				delete(producerMessageContexts, key)
-				finishProducerSpan(mc.span, msg.Partition, msg.Offset, nil)
+				inst.finishProducerSpan(mc, msg, msg.Partition, msg.Offset, nil)
 				msg.Metadata = mc.metadataBackup // Restore message metadata
 			}
 			mtx.Unlock()
@@ -224,7 +300,7 @@ func WrapAsyncProducer(saramaConfig *sarama.Config, p sarama.AsyncProducer, opts
 			mtx.Lock()
 			if mc, ok := producerMessageContexts[key]; ok {
 				delete(producerMessageContexts, key)
-				finishProducerSpan(mc.span, errMsg.Msg.Partition, errMsg.Msg.Offset, errMsg.Err)
+				inst.finishProducerSpan(mc, errMsg.Msg, errMsg.Msg.Partition, errMsg.Msg.Offset, errMsg.Err)
 				errMsg.Msg.Metadata = mc.metadataBackup // Restore message metadata
 			}
 			mtx.Unlock()
@@ -278,17 +354,18 @@ func msgPayloadSize(msg *sarama.ProducerMessage, kafkaVersion sarama.KafkaVersio
 	return size
 }
 
-func startProducerSpan(cfg config, version sarama.KafkaVersion, msg *sarama.ProducerMessage) trace.Span {
+func startProducerSpan(cfg config, version sarama.KafkaVersion, msg *sarama.ProducerMessage) producerMessageContext {
 	// If there's a span context in the message, use that as the parent context.
 	carrier := NewProducerMessageCarrier(msg)
 	ctx := cfg.Propagators.Extract(context.Background(), carrier)
 
 	// Create a span.
+	size := msgPayloadSize(msg, version)
 	attrs := []attribute.KeyValue{
 		semconv.MessagingSystem("kafka"),
 		semconv.MessagingDestinationKindTopic,
 		semconv.MessagingDestinationName(msg.Topic),
-		semconv.MessagingMessagePayloadSizeBytes(msgPayloadSize(msg, version)),
+		semconv.MessagingMessagePayloadSizeBytes(size),
 		semconv.MessagingOperationPublish,
 	}
 	opts := []trace.SpanStartOption{
@@ -302,16 +379,38 @@ func startProducerSpan(cfg config, version sarama.KafkaVersion, msg *sarama.Prod
 		cfg.Propagators.Inject(ctx, carrier)
 	}
 
-	return span
+	return producerMessageContext{span: span, start: time.Now(), size: size}
 }
 
-func finishProducerSpan(span trace.Span, partition int32, offset int64, err error) {
-	span.SetAttributes(
+// finishProducerSpan ends the span of mc and records the publish metrics of
+// msg.
+func (i instruments) finishProducerSpan(mc producerMessageContext, msg *sarama.ProducerMessage, partition int32, offset int64, err error) {
+	mc.span.SetAttributes(
 		semconv.MessagingMessageID(strconv.FormatInt(offset, 10)),
 		semconv.MessagingKafkaDestinationPartition(int(partition)),
 	)
 	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
+		mc.span.SetStatus(codes.Error, err.Error())
+	}
+	mc.span.End()
+
+	ctx := trace.ContextWithSpan(context.Background(), mc.span)
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystem("kafka"),
+		semconv.MessagingDestinationName(msg.Topic),
+	}
+	// The partition is not known if the message failed before being
+	// assigned one.
+	if partition >= 0 {
+		attrs = append(attrs, semconv.MessagingKafkaDestinationPartition(int(partition)))
+	}
+	o := metric.WithAttributes(attrs...)
+	if err != nil {
+		i.publishErrors.Add(ctx, 1, o)
+		return
 	}
-	span.End()
+	elapsed := float64(time.Since(mc.start)) / float64(time.Millisecond)
+	i.publishDuration.Record(ctx, elapsed, o)
+	i.publishMessages.Add(ctx, 1, o)
+	i.publishBytes.Add(ctx, int64(mc.size), o)
 }
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama" //nolint:staticcheck // This is deprecated and will be removed in the next release.
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+func TestProducerMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	cfg := newSaramaConfig()
+	mockSyncProducer := mocks.NewSyncProducer(t, cfg)
+	mockSyncProducer.ExpectSendMessageAndSucceed()
+	mockSyncProducer.ExpectSendMessageAndSucceed()
+	mockSyncProducer.ExpectSendMessageAndFail(errors.New("test"))
+
+	producer := otelsarama.WrapSyncProducer(cfg, mockSyncProducer, otelsarama.WithMeterProvider(provider))
+	for i := 0; i < 3; i++ {
+		_, _, _ = producer.SendMessage(&sarama.ProducerMessage{Topic: topic, Value: sarama.StringEncoder("foo")})
+	}
+	require.NoError(t, mockSyncProducer.Close())
+
+	metrics := collect(t, reader)
+	attrs := attribute.NewSet(
+		semconv.MessagingSystem("kafka"),
+		semconv.MessagingDestinationName(topic),
+		semconv.MessagingKafkaDestinationPartition(0),
+	)
+
+	duration, ok := metrics[otelsarama.PublishDuration].Data.(metricdata.Histogram[float64])
+	require.True(t, ok, "missing %s", otelsarama.PublishDuration)
+	require.Len(t, duration.DataPoints, 1)
+	assert.Equal(t, attrs, duration.DataPoints[0].Attributes)
+	assert.Equal(t, uint64(2), duration.DataPoints[0].Count)
+
+	assertSum(t, metrics, otelsarama.PublishMessages, attrs, 2)
+	// The partition of a failed message is not known.
+	assertSum(t, metrics, otelsarama.PublishErrors, attribute.NewSet(
+		semconv.MessagingSystem("kafka"),
+		semconv.MessagingDestinationName(topic),
+	), 1)
+
+	bytes, ok := metrics[otelsarama.PublishBytes].Data.(metricdata.Sum[int64])
+	require.True(t, ok, "missing %s", otelsarama.PublishBytes)
+	require.Len(t, bytes.DataPoints, 1)
+	assert.Greater(t, bytes.DataPoints[0].Value, int64(2*len("foo")))
+}
+
+func TestConsumerMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	consumer := mocks.NewConsumer(t, sarama.NewConfig())
+	mockPartitionConsumer := consumer.ExpectConsumePartition(topic, 0, 0)
+	partitionConsumer, err := consumer.ConsumePartition(topic, 0, 0)
+	require.NoError(t, err)
+	partitionConsumer = otelsarama.WrapPartitionConsumer(partitionConsumer, otelsarama.WithMeterProvider(provider))
+	offsetManager := otelsarama.WrapPartitionOffsetManager(&partitionOffsetManager{}, partitionConsumer)
+
+	for i := 0; i < 3; i++ {
+		mockPartitionConsumer.YieldMessage(&sarama.ConsumerMessage{Value: []byte("foo")})
+	}
+	for i := 0; i < 2; i++ {
+		msg := <-partitionConsumer.Messages()
+		offsetManager.MarkOffset(msg.Offset+1, "")
+	}
+
+	metrics := collect(t, reader)
+	attrs := attribute.NewSet(
+		semconv.MessagingSystem("kafka"),
+		semconv.MessagingDestinationName(topic),
+		semconv.MessagingKafkaSourcePartition(0),
+	)
+
+	// The wrapper receives the third message while the second one is
+	// consumed.
+	received, ok := metrics[otelsarama.ReceiveMessages].Data.(metricdata.Sum[int64])
+	require.True(t, ok, "missing %s", otelsarama.ReceiveMessages)
+	require.Len(t, received.DataPoints, 1)
+	assert.Equal(t, attrs, received.DataPoints[0].Attributes)
+	assert.GreaterOrEqual(t, received.DataPoints[0].Value, int64(2))
+
+	// Two messages are committed.
+	assertLag(t, metrics, attrs, mockPartitionConsumer.HighWaterMarkOffset()-2)
+
+	require.NoError(t, partitionConsumer.Close())
+	for range partitionConsumer.Messages() {
+	}
+
+	// The lag is no longer observed once the consumer is closed.
+	metrics = collect(t, reader)
+	_, ok = metrics[otelsarama.ConsumerLag].Data.(metricdata.Gauge[int64])
+	assert.False(t, ok)
+}
+
+func TestConsumerGroupMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+	attrs := attribute.NewSet(
+		semconv.MessagingSystem("kafka"),
+		semconv.MessagingDestinationName(topic),
+		semconv.MessagingKafkaSourcePartition(0),
+	)
+
+	// The group committed offset 5 of the partition, which has 10 messages.
+	claim := &consumerGroupClaim{
+		messages:            make(chan *sarama.ConsumerMessage, 1),
+		partition:           0,
+		initialOffset:       5,
+		highWaterMarkOffset: 10,
+	}
+	claim.messages <- &sarama.ConsumerMessage{Topic: topic, Partition: 0, Offset: 5}
+	handler := otelsarama.WrapConsumerGroupHandler(consumerGroupHandler(func(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+		assertLag(t, collect(t, reader), attrs, 5)
+
+		session.MarkMessage(<-claim.Messages(), "")
+		assertLag(t, collect(t, reader), attrs, 4)
+
+		session.ResetOffset(topic, 0, 2, "")
+		assertLag(t, collect(t, reader), attrs, 8)
+		return nil
+	}), otelsarama.WithMeterProvider(provider))
+
+	require.NoError(t, handler.ConsumeClaim(&consumerGroupSession{ctx: context.Background()}, claim))
+	close(claim.messages)
+}
+
+func assertLag(t *testing.T, metrics map[string]metricdata.Metrics, attrs attribute.Set, want int64) {
+	t.Helper()
+	lag, ok := metrics[otelsarama.ConsumerLag].Data.(metricdata.Gauge[int64])
+	require.True(t, ok, "missing %s", otelsarama.ConsumerLag)
+	require.Len(t, lag.DataPoints, 1)
+	assert.Equal(t, attrs, lag.DataPoints[0].Attributes)
+	assert.Equal(t, want, lag.DataPoints[0].Value)
+}
+
+type partitionOffsetManager struct {
+	sarama.PartitionOffsetManager
+	offset int64
+}
+
+func (pom *partitionOffsetManager) NextOffset() (int64, string) { return pom.offset, "" }
+func (pom *partitionOffsetManager) MarkOffset(offset int64, _ string) {
+	if offset > pom.offset {
+		pom.offset = offset
+	}
+}
+
+type consumerGroupHandler func(sarama.ConsumerGroupSession, sarama.ConsumerGroupClaim) error
+
+func (consumerGroupHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (consumerGroupHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+func (h consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	return h(session, claim)
+}
+
+func collect(t *testing.T, reader metric.Reader) map[string]metricdata.Metrics {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	metrics := map[string]metricdata.Metrics{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+	return metrics
+}
+
+func assertSum(t *testing.T, metrics map[string]metricdata.Metrics, name string, attrs attribute.Set, want int64) {
+	sum, ok := metrics[name].Data.(metricdata.Sum[int64])
+	require.True(t, ok, "missing %s", name)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, attrs, sum.DataPoints[0].Attributes)
+	assert.Equal(t, want, sum.DataPoints[0].Value)
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/Shopify/sarama/otelsarama" //nolint:staticcheck // This is deprecated and will be removed in the next release.
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type consumerGroupSession struct {
+	sarama.ConsumerGroupSession
+
+	ctx    context.Context
+	marked []*sarama.ConsumerMessage
+}
+
+func (s *consumerGroupSession) Context() context.Context { return s.ctx }
+
+func (s *consumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg)
+}
+
+func (s *consumerGroupSession) ResetOffset(string, int32, int64, string) {}
+
+type consumerGroupClaim struct {
+	sarama.ConsumerGroupClaim
+
+	messages            chan *sarama.ConsumerMessage
+	partition           int32
+	initialOffset       int64
+	highWaterMarkOffset int64
+}
+
+func (c *consumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+func (c *consumerGroupClaim) Topic() string                            { return topic }
+func (c *consumerGroupClaim) Partition() int32                         { return c.partition }
+func (c *consumerGroupClaim) InitialOffset() int64                     { return c.initialOffset }
+func (c *consumerGroupClaim) HighWaterMarkOffset() int64               { return c.highWaterMarkOffset }
+
+func TestConsumeClaim(t *testing.T) {
+	propagators := propagation.TraceContext{}
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	// Propagate the span of the producer in the first message.
+	producerCtx, producerSpan := provider.Tracer("test").Start(context.Background(), "producer")
+	producerSpan.End()
+	ok := &sarama.ConsumerMessage{Topic: topic, Offset: 1, Key: []byte("ok")}
+	propagators.Inject(producerCtx, otelsarama.NewConsumerMessageCarrier(ok))
+	failed := &sarama.ConsumerMessage{Topic: topic, Offset: 2, Key: []byte("failed")}
+
+	claim := &consumerGroupClaim{messages: make(chan *sarama.ConsumerMessage, 2)}
+	claim.messages <- ok
+	claim.messages <- failed
+	close(claim.messages)
+	session := &consumerGroupSession{ctx: context.Background()}
+
+	errProcess := errors.New("process error")
+	var processed []trace.SpanContext
+	err := otelsarama.ConsumeClaim(session, claim, func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		processed = append(processed, trace.SpanContextFromContext(ctx))
+		if msg == failed {
+			return errProcess
+		}
+		return nil
+	}, otelsarama.WithTracerProvider(provider), otelsarama.WithPropagators(propagators))
+	require.NoError(t, err)
+
+	assert.Equal(t, []*sarama.ConsumerMessage{ok}, session.marked)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	okSpan, failedSpan := spans[1], spans[2]
+	for i, span := range []sdktrace.ReadOnlySpan{okSpan, failedSpan} {
+		assert.Equal(t, topic+" process", span.Name())
+		assert.Equal(t, trace.SpanKindConsumer, span.SpanKind())
+		assert.Contains(t, span.Attributes(), semconv.MessagingOperationProcess)
+		assert.Contains(t, span.Attributes(), semconv.MessagingDestinationName(topic))
+		assert.Equal(t, processed[i], span.SpanContext(), "handler should run within the process span")
+	}
+
+	assert.Equal(t, producerSpan.SpanContext().SpanID(), okSpan.Parent().SpanID())
+	assert.Contains(t, okSpan.Attributes(), semconv.MessagingMessageID("1"))
+	assert.Contains(t, okSpan.Attributes(), otelsarama.OffsetMarkedKey.Bool(true))
+	assert.Equal(t, codes.Unset, okSpan.Status().Code)
+
+	assert.False(t, failedSpan.Parent().IsValid())
+	assert.Contains(t, failedSpan.Attributes(), semconv.MessagingMessageID("2"))
+	assert.Contains(t, failedSpan.Attributes(), otelsarama.OffsetMarkedKey.Bool(false))
+	assert.Equal(t, codes.Error, failedSpan.Status().Code)
+	assert.Equal(t, errProcess.Error(), failedSpan.Status().Description)
+	require.Len(t, failedSpan.Events(), 1)
+	assert.Equal(t, semconv.ExceptionEventName, failedSpan.Events()[0].Name)
+}
+
+func TestConsumeClaimSessionDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	session := &consumerGroupSession{ctx: ctx}
+	claim := &consumerGroupClaim{messages: make(chan *sarama.ConsumerMessage)}
+
+	err := otelsarama.ConsumeClaim(session, claim, func(context.Context, *sarama.ConsumerMessage) error {
+		t.Fatal("no message should be processed")
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestStartProcessSpan(t *testing.T) {
+	propagators := propagation.TraceContext{}
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	producerCtx, producerSpan := provider.Tracer("test").Start(context.Background(), "producer")
+	producerSpan.End()
+	msg := &sarama.ConsumerMessage{Topic: topic, Partition: 3, Offset: 7}
+	propagators.Inject(producerCtx, otelsarama.NewConsumerMessageCarrier(msg))
+
+	ctx, span := otelsarama.StartProcessSpan(context.Background(), msg,
+		otelsarama.WithTracerProvider(provider),
+		otelsarama.WithPropagators(propagators),
+	)
+	assert.Equal(t, span.SpanContext(), trace.SpanContextFromContext(ctx))
+	span.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, topic+" process", spans[1].Name())
+	assert.Equal(t, producerSpan.SpanContext().SpanID(), spans[1].Parent().SpanID())
+	assert.Contains(t, spans[1].Attributes(), semconv.MessagingKafkaSourcePartition(3))
+	assert.Contains(t, spans[1].Attributes(), semconv.MessagingMessageID("7"))
+}
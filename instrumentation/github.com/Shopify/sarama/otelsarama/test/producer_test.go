@@ -144,6 +144,89 @@ func TestWrapSyncProducer(t *testing.T) {
 	}
 }
 
+// partialFailureSyncProducer is a sarama.SyncProducer failing to send the
+// messages whose key is in fail.
+type partialFailureSyncProducer struct {
+	sarama.SyncProducer
+
+	fail map[string]error
+}
+
+func (p partialFailureSyncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	var errs sarama.ProducerErrors
+	for i, msg := range msgs {
+		key, _ := msg.Key.Encode()
+		if err, ok := p.fail[string(key)]; ok {
+			errs = append(errs, &sarama.ProducerError{Msg: msg, Err: err})
+			continue
+		}
+		msg.Partition, msg.Offset = 1, int64(i)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func TestWrapSyncProducerSendMessages(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+
+	errFoo2 := errors.New("foo2")
+	producer := otelsarama.WrapSyncProducer(newSaramaConfig(), partialFailureSyncProducer{
+		fail: map[string]error{"foo2": errFoo2},
+	}, otelsarama.WithTracerProvider(provider))
+
+	msgs := []*sarama.ProducerMessage{
+		{Topic: topic, Key: sarama.StringEncoder("foo1")},
+		{Topic: topic, Key: sarama.StringEncoder("foo2")},
+		{Topic: topic, Key: sarama.StringEncoder("foo3")},
+	}
+	err := producer.SendMessages(msgs)
+	require.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, len(msgs)+1)
+
+	batch := spans[len(msgs)]
+	assert.Equal(t, fmt.Sprintf("%s publish", topic), batch.Name())
+	assert.Equal(t, oteltrace.SpanKindProducer, batch.SpanKind())
+	assert.Contains(t, batch.Attributes(), semconv.MessagingBatchMessageCount(len(msgs)))
+	assert.Equal(t, codes.Error, batch.Status().Code)
+	require.Len(t, batch.Links(), len(msgs))
+
+	for i, span := range spans[:len(msgs)] {
+		assert.Equal(t, span.SpanContext(), batch.Links()[i].SpanContext)
+		if i == 1 {
+			assert.Equal(t, codes.Error, span.Status().Code)
+			assert.Equal(t, errFoo2.Error(), span.Status().Description)
+		} else {
+			assert.Equal(t, codes.Unset, span.Status().Code)
+			assert.Contains(t, span.Attributes(), semconv.MessagingKafkaDestinationPartition(1))
+		}
+	}
+}
+
+func TestWrapSyncProducerSendMessagesMixedTopics(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+
+	producer := otelsarama.WrapSyncProducer(newSaramaConfig(), partialFailureSyncProducer{}, otelsarama.WithTracerProvider(provider))
+	require.NoError(t, producer.SendMessages([]*sarama.ProducerMessage{
+		{Topic: topic, Key: sarama.StringEncoder("foo1")},
+		{Topic: "other-topic", Key: sarama.StringEncoder("foo2")},
+	}))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 3)
+	batch := spans[2]
+	assert.Equal(t, "publish", batch.Name())
+	assert.Equal(t, codes.Unset, batch.Status().Code)
+	for _, kv := range batch.Attributes() {
+		assert.NotEqual(t, semconv.MessagingDestinationNameKey, kv.Key)
+	}
+}
+
 func TestWrapAsyncProducer(t *testing.T) {
 	propagators := propagation.TraceContext{}
 	// Create message with span context
@@ -17,6 +17,7 @@ package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -46,13 +47,6 @@ var _ Flusher = &noopFlusher{}
 // trace information is instead stored in the Lambda environment.
 type EventToCarrier func(eventJSON []byte) propagation.TextMapCarrier
 
-func emptyEventToCarrier([]byte) propagation.TextMapCarrier {
-	return propagation.HeaderCarrier{}
-}
-
-// Compile time check our emptyEventToCarrier implements EventToCarrier.
-var _ EventToCarrier = emptyEventToCarrier
-
 // Option applies a configuration option.
 type Option interface {
 	apply(*config)
@@ -71,6 +65,14 @@ type config struct {
 	// returned by otel.GetTracerProvider()
 	TracerProvider trace.TracerProvider
 
+	// MeterProvider is the MeterProvider which will be used
+	// to create the instruments recording invocations
+	// The default value of MeterProvider the global otel MeterProvider
+	// returned by otel.GetMeterProvider()
+	// The MeterProvider is flushed at the end of each invocation
+	// if it implements Flusher
+	MeterProvider metric.MeterProvider
+
 	// Flusher is the mechanism used to flush any unexported spans
 	// each Lambda Invocation to avoid spans being unexported for long
 	// when periods of time if Lambda freezes the execution environment
@@ -81,9 +83,12 @@ type config struct {
 	// EventToCarrier is the mechanism used to retrieve the TraceID
 	// from the event or environment and generate a TextMapCarrier which
 	// can then be used by a Propagator to extract the TraceID into our context
-	// The default value of eventToCarrier is emptyEventToCarrier which returns
-	// an empty HeaderCarrier, using this default will cause new spans to be part
-	// of a new Trace and have no parent past our Lambda instrumentation span
+	// The default, used when EventToCarrier is nil, returns the headers of
+	// API Gateway and Application Load Balancer requests, from the event
+	// decoded once to also describe the invocation, and an empty
+	// HeaderCarrier for other events, using this default will cause
+	// spans of other invocations to be part of a new Trace and have no parent
+	// past our Lambda instrumentation span
 	EventToCarrier EventToCarrier
 
 	// Propagator is the Propagator which will be used
@@ -103,6 +108,19 @@ func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider configures the MeterProvider used by the
+// instrumentation.
+//
+// By default, the global MeterProvider is used. A nil meterProvider is
+// ignored.
+func WithMeterProvider(meterProvider metric.MeterProvider) Option {
+	return optionFunc(func(c *config) {
+		if meterProvider != nil {
+			c.MeterProvider = meterProvider
+		}
+	})
+}
+
 // WithFlusher sets the used flusher.
 func WithFlusher(flusher Flusher) Option {
 	return optionFunc(func(c *config) {
@@ -0,0 +1,381 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// eventSource is the AWS service which triggered a Lambda invocation.
+type eventSource int
+
+const (
+	unknownSource eventSource = iota
+	apiGatewayRESTSource
+	apiGatewayHTTPSource
+	albSource
+	sqsSource
+	snsSource
+	kinesisSource
+	eventBridgeSource
+)
+
+// multipleSources is the source name of the spans of invocations triggered
+// by records of different queues, topics or streams.
+const multipleSources = "multiple_sources"
+
+// xrayTraceHeader is the key of the AWS X-Ray trace header.
+const xrayTraceHeader = "X-Amzn-Trace-Id"
+
+// lambdaEvent holds the fields of the events of the supported event sources
+// needed to identify the source of an invocation and to describe it. The
+// fields of the different events do not overlap.
+type lambdaEvent struct {
+	// API Gateway REST and HTTP APIs, Application Load Balancer.
+	Version           string              `json:"version"`
+	RouteKey          string              `json:"routeKey"`
+	RawPath           string              `json:"rawPath"`
+	RawQueryString    string              `json:"rawQueryString"`
+	Resource          string              `json:"resource"`
+	Path              string              `json:"path"`
+	HTTPMethod        string              `json:"httpMethod"`
+	Headers           map[string]string   `json:"headers"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders"`
+	RequestContext    *requestContext     `json:"requestContext"`
+
+	// SQS, SNS and Kinesis.
+	Records []eventRecord `json:"Records"`
+
+	// EventBridge.
+	ID         string `json:"id"`
+	DetailType string `json:"detail-type"`
+	Source     string `json:"source"`
+}
+
+type requestContext struct {
+	ELB *struct {
+		TargetGroupARN string `json:"targetGroupArn"`
+	} `json:"elb"`
+	HTTP struct {
+		Method    string `json:"method"`
+		SourceIP  string `json:"sourceIp"`
+		UserAgent string `json:"userAgent"`
+	} `json:"http"`
+	Identity struct {
+		SourceIP  string `json:"sourceIp"`
+		UserAgent string `json:"userAgent"`
+	} `json:"identity"`
+}
+
+type eventRecord struct {
+	// EventSource also matches the EventSource field of SNS records.
+	EventSource    string `json:"eventSource"`
+	EventSourceARN string `json:"eventSourceARN"`
+
+	// SQS.
+	MessageID         string                         `json:"messageId"`
+	Attributes        map[string]string              `json:"attributes"`
+	MessageAttributes map[string]sqsMessageAttribute `json:"messageAttributes"`
+
+	// SNS.
+	SNS *struct {
+		MessageID         string                         `json:"MessageId"`
+		TopicArn          string                         `json:"TopicArn"`
+		MessageAttributes map[string]snsMessageAttribute `json:"MessageAttributes"`
+	} `json:"Sns"`
+}
+
+type sqsMessageAttribute struct {
+	StringValue *string `json:"stringValue"`
+	DataType    string  `json:"dataType"`
+}
+
+type snsMessageAttribute struct {
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+}
+
+// parseEvent decodes the fields of eventJSON needed to describe the
+// invocation. Events which are not JSON objects are of an unknown source.
+func parseEvent(eventJSON []byte) lambdaEvent {
+	var event lambdaEvent
+	_ = json.Unmarshal(eventJSON, &event)
+	return event
+}
+
+func (e lambdaEvent) source() eventSource {
+	if len(e.Records) > 0 {
+		switch e.Records[0].EventSource {
+		case "aws:sqs":
+			return sqsSource
+		case "aws:sns":
+			return snsSource
+		case "aws:kinesis":
+			return kinesisSource
+		}
+		return unknownSource
+	}
+	switch {
+	case e.RequestContext != nil && e.RequestContext.ELB != nil:
+		return albSource
+	case e.RequestContext != nil && e.Version == "2.0" && e.RequestContext.HTTP.Method != "":
+		// Lambda function URLs share the format of API Gateway HTTP APIs.
+		return apiGatewayHTTPSource
+	case e.RequestContext != nil && e.HTTPMethod != "":
+		return apiGatewayRESTSource
+	case e.DetailType != "" && e.Source != "":
+		return eventBridgeSource
+	}
+	return unknownSource
+}
+
+// eventToCarrier returns the carrier of the default EventToCarrier.
+func eventToCarrier(eventJSON []byte) propagation.TextMapCarrier {
+	return parseEvent(eventJSON).carrier()
+}
+
+// Compile time check our eventToCarrier implements EventToCarrier.
+var _ EventToCarrier = eventToCarrier
+
+// carrier returns the headers of the HTTP requests of API Gateway and
+// Application Load Balancer events, and an empty carrier for other events.
+func (e lambdaEvent) carrier() propagation.TextMapCarrier {
+	switch e.source() {
+	case apiGatewayRESTSource, apiGatewayHTTPSource, albSource:
+		return propagation.HeaderCarrier(e.header())
+	}
+	return propagation.HeaderCarrier{}
+}
+
+// header returns the headers of the HTTP request of the event.
+func (e lambdaEvent) header() http.Header {
+	h := make(http.Header, len(e.Headers))
+	for k, v := range e.Headers {
+		h.Set(k, v)
+	}
+	for k, v := range e.MultiValueHeaders {
+		h[http.CanonicalHeaderKey(k)] = v
+	}
+	return h
+}
+
+// invocationSpan describes the span of an invocation.
+type invocationSpan struct {
+	name       string
+	kind       trace.SpanKind
+	attributes []attribute.KeyValue
+	links      []trace.Link
+}
+
+// span returns the description of the span of an invocation triggered by e.
+// Invocations of unknown sources are described by a server span named name.
+// The span contexts propagated by the records of messaging events are
+// extracted with propagator and linked to the span.
+func (e lambdaEvent) span(name string, propagator propagation.TextMapPropagator) invocationSpan {
+	switch e.source() {
+	case apiGatewayRESTSource:
+		return e.httpSpan(name, e.HTTPMethod, e.Resource, e.Path, e.RequestContext.Identity.SourceIP, e.RequestContext.Identity.UserAgent)
+	case apiGatewayHTTPSource:
+		// The route key of HTTP APIs is the method followed by the route, or
+		// $default.
+		var route string
+		if i := strings.IndexByte(e.RouteKey, ' '); i >= 0 {
+			route = e.RouteKey[i+1:]
+		}
+		target := e.RawPath
+		if e.RawQueryString != "" {
+			target += "?" + e.RawQueryString
+		}
+		return e.httpSpan(name, e.RequestContext.HTTP.Method, route, target, e.RequestContext.HTTP.SourceIP, e.RequestContext.HTTP.UserAgent)
+	case albSource:
+		return e.httpSpan(name, e.HTTPMethod, "", e.Path, "", "")
+	case sqsSource:
+		return e.sqsSpan(propagator)
+	case snsSource:
+		return e.snsSpan(propagator)
+	case kinesisSource:
+		return e.kinesisSpan()
+	case eventBridgeSource:
+		return invocationSpan{
+			name: e.Source + " process",
+			kind: trace.SpanKindConsumer,
+			attributes: []attribute.KeyValue{
+				semconv.FaaSTriggerPubsub,
+				semconv.MessagingSystem("AmazonEventBridge"),
+				semconv.MessagingOperationProcess,
+				semconv.MessagingSourceName(e.Source),
+				semconv.MessagingMessageID(e.ID),
+			},
+		}
+	}
+	return invocationSpan{name: name, kind: trace.SpanKindServer}
+}
+
+// httpSpan describes the server span of an HTTP request. The span is named
+// after the route, if known, and name otherwise.
+func (e lambdaEvent) httpSpan(name, method, route, target, clientIP, userAgent string) invocationSpan {
+	h := e.header()
+	attrs := []attribute.KeyValue{
+		semconv.FaaSTriggerHTTP,
+		semconv.HTTPMethod(method),
+		semconv.HTTPTarget(target),
+	}
+	if route != "" {
+		name = route
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+	if scheme := h.Get("X-Forwarded-Proto"); scheme != "" {
+		attrs = append(attrs, semconv.HTTPScheme(scheme))
+	}
+	if host := h.Get("Host"); host != "" {
+		attrs = append(attrs, semconv.NetHostName(host))
+	}
+	if userAgent == "" {
+		userAgent = h.Get("User-Agent")
+	}
+	if userAgent != "" {
+		attrs = append(attrs, semconv.HTTPUserAgent(userAgent))
+	}
+	if clientIP == "" {
+		// The Application Load Balancer appends the IP address of the
+		// client to the X-Forwarded-For header.
+		if xff := h.Get("X-Forwarded-For"); xff != "" {
+			clientIP = strings.TrimSpace(xff[strings.LastIndexByte(xff, ',')+1:])
+		}
+	}
+	if clientIP != "" {
+		attrs = append(attrs, semconv.HTTPClientIP(clientIP))
+	}
+	return invocationSpan{name: name, kind: trace.SpanKindServer, attributes: attrs}
+}
+
+// messagingSpan describes the consumer span processing the records of a
+// messaging event received from the sources.
+func messagingSpan(system string, sources []string, count int) invocationSpan {
+	span := invocationSpan{
+		name: "process",
+		kind: trace.SpanKindConsumer,
+		attributes: []attribute.KeyValue{
+			semconv.FaaSTriggerPubsub,
+			semconv.MessagingSystem(system),
+			semconv.MessagingOperationProcess,
+			semconv.MessagingBatchMessageCount(count),
+		},
+	}
+	if len(sources) > 0 {
+		source := multipleSources
+		if len(sources) == 1 {
+			source = sources[0]
+		}
+		span.name = source + " process"
+		span.attributes = append(span.attributes, semconv.MessagingSourceName(source))
+	}
+	return span
+}
+
+// sqsSpan describes the consumer span processing the batch of SQS messages
+// of the event, which is linked to the span context propagated by each
+// message, if any.
+func (e lambdaEvent) sqsSpan(propagator propagation.TextMapPropagator) invocationSpan {
+	sources := make([]string, 0, 1)
+	var links []trace.Link
+	for _, r := range e.Records {
+		sources = appendSource(sources, r.EventSourceARN[strings.LastIndexByte(r.EventSourceARN, ':')+1:])
+
+		carrier := propagation.MapCarrier{}
+		for k, v := range r.MessageAttributes {
+			if v.StringValue != nil {
+				carrier.Set(k, *v.StringValue)
+			}
+		}
+		// The AWS X-Ray trace header is a system attribute of SQS messages.
+		if header, ok := r.Attributes["AWSTraceHeader"]; ok {
+			carrier.Set(xrayTraceHeader, header)
+		}
+		links = appendLink(links, propagator, carrier, r.MessageID)
+	}
+
+	span := messagingSpan("AmazonSQS", sources, len(e.Records))
+	span.attributes = append(span.attributes, semconv.MessagingSourceKindQueue)
+	span.links = links
+	return span
+}
+
+func (e lambdaEvent) snsSpan(propagator propagation.TextMapPropagator) invocationSpan {
+	sources := make([]string, 0, 1)
+	var links []trace.Link
+	for _, r := range e.Records {
+		if r.SNS == nil {
+			continue
+		}
+		sources = appendSource(sources, r.SNS.TopicArn[strings.LastIndexByte(r.SNS.TopicArn, ':')+1:])
+
+		carrier := propagation.MapCarrier{}
+		for k, v := range r.SNS.MessageAttributes {
+			if v.Type == "String" {
+				carrier.Set(k, v.Value)
+			}
+		}
+		links = appendLink(links, propagator, carrier, r.SNS.MessageID)
+	}
+
+	span := messagingSpan("AmazonSNS", sources, len(e.Records))
+	span.attributes = append(span.attributes, semconv.MessagingSourceKindTopic)
+	span.links = links
+	return span
+}
+
+func (e lambdaEvent) kinesisSpan() invocationSpan {
+	sources := make([]string, 0, 1)
+	for _, r := range e.Records {
+		sources = appendSource(sources, r.EventSourceARN[strings.LastIndexByte(r.EventSourceARN, '/')+1:])
+	}
+	return messagingSpan("AmazonKinesis", sources, len(e.Records))
+}
+
+// appendSource appends source to sources if it is not empty nor already
+// present.
+func appendSource(sources []string, source string) []string {
+	if source == "" {
+		return sources
+	}
+	for _, s := range sources {
+		if s == source {
+			return sources
+		}
+	}
+	return append(sources, source)
+}
+
+// appendLink appends a link to the span context extracted from carrier by
+// propagator, if any, to links.
+func appendLink(links []trace.Link, propagator propagation.TextMapPropagator, carrier propagation.TextMapCarrier, messageID string) []trace.Link {
+	sc := trace.SpanContextFromContext(propagator.Extract(context.Background(), carrier))
+	if !sc.IsValid() {
+		return links
+	}
+	return append(links, trace.Link{
+		SpanContext: sc,
+		Attributes:  []attribute.KeyValue{semconv.MessagingMessageID(messageID)},
+	})
+}
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceparent = "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01"
+
+	apiGatewayRESTEvent = `{
+		"resource": "/pets/{id}",
+		"path": "/pets/42",
+		"httpMethod": "GET",
+		"headers": {"Host": "example.com", "X-Forwarded-Proto": "https"},
+		"multiValueHeaders": {"traceparent": ["` + traceparent + `"]},
+		"requestContext": {"identity": {"sourceIp": "192.0.2.1", "userAgent": "test-agent"}}
+	}`
+
+	apiGatewayHTTPEvent = `{
+		"version": "2.0",
+		"routeKey": "POST /pets",
+		"rawPath": "/pets",
+		"rawQueryString": "kind=cat",
+		"headers": {"host": "example.com", "traceparent": "` + traceparent + `"},
+		"requestContext": {"http": {"method": "POST", "sourceIp": "192.0.2.1", "userAgent": "test-agent"}}
+	}`
+
+	albEvent = `{
+		"httpMethod": "GET",
+		"path": "/health",
+		"headers": {"x-forwarded-for": "192.0.2.1, 198.51.100.1", "traceparent": "` + traceparent + `"},
+		"requestContext": {"elb": {"targetGroupArn": "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/tg/1"}}
+	}`
+
+	sqsEvent = `{"Records": [
+		{
+			"messageId": "1",
+			"eventSource": "aws:sqs",
+			"eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:test-queue",
+			"messageAttributes": {"traceparent": {"stringValue": "` + traceparent + `", "dataType": "String"}}
+		},
+		{
+			"messageId": "2",
+			"eventSource": "aws:sqs",
+			"eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:test-queue"
+		}
+	]}`
+
+	snsEvent = `{"Records": [{
+		"EventSource": "aws:sns",
+		"Sns": {
+			"MessageId": "1",
+			"TopicArn": "arn:aws:sns:us-east-1:123456789012:test-topic",
+			"MessageAttributes": {"traceparent": {"Type": "String", "Value": "` + traceparent + `"}}
+		}
+	}]}`
+
+	kinesisEvent = `{"Records": [
+		{"eventSource": "aws:kinesis", "eventSourceARN": "arn:aws:kinesis:us-east-1:123456789012:stream/stream-1"},
+		{"eventSource": "aws:kinesis", "eventSourceARN": "arn:aws:kinesis:us-east-1:123456789012:stream/stream-2"}
+	]}`
+
+	eventBridgeEvent = `{
+		"id": "1",
+		"detail-type": "Test Event",
+		"source": "test.source",
+		"detail": {}
+	}`
+)
+
+func TestEventSource(t *testing.T) {
+	for event, want := range map[string]eventSource{
+		apiGatewayRESTEvent:             apiGatewayRESTSource,
+		apiGatewayHTTPEvent:             apiGatewayHTTPSource,
+		albEvent:                        albSource,
+		sqsEvent:                        sqsSource,
+		snsEvent:                        snsSource,
+		kinesisEvent:                    kinesisSource,
+		eventBridgeEvent:                eventBridgeSource,
+		`{"Headers": {"key": "value"}}`: unknownSource,
+		`"string event"`:                unknownSource,
+		``:                              unknownSource,
+	} {
+		assert.Equal(t, want, parseEvent([]byte(event)).source(), event)
+	}
+}
+
+func TestEventToCarrier(t *testing.T) {
+	for _, event := range []string{apiGatewayRESTEvent, apiGatewayHTTPEvent, albEvent} {
+		assert.Equal(t, traceparent, eventToCarrier([]byte(event)).Get("traceparent"), event)
+	}
+	assert.Empty(t, eventToCarrier([]byte(sqsEvent)).Keys())
+}
+
+func TestHTTPEventSpans(t *testing.T) {
+	span := parseEvent([]byte(apiGatewayRESTEvent)).span("testFunction", propagation.TraceContext{})
+	assert.Equal(t, "/pets/{id}", span.name)
+	assert.Equal(t, trace.SpanKindServer, span.kind)
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.FaaSTriggerHTTP,
+		semconv.HTTPMethod("GET"),
+		semconv.HTTPTarget("/pets/42"),
+		semconv.HTTPRoute("/pets/{id}"),
+		semconv.HTTPScheme("https"),
+		semconv.NetHostName("example.com"),
+		semconv.HTTPUserAgent("test-agent"),
+		semconv.HTTPClientIP("192.0.2.1"),
+	}, span.attributes)
+
+	span = parseEvent([]byte(apiGatewayHTTPEvent)).span("testFunction", propagation.TraceContext{})
+	assert.Equal(t, "/pets", span.name)
+	assert.Equal(t, trace.SpanKindServer, span.kind)
+	assert.Contains(t, span.attributes, semconv.HTTPMethod("POST"))
+	assert.Contains(t, span.attributes, semconv.HTTPTarget("/pets?kind=cat"))
+	assert.Contains(t, span.attributes, semconv.HTTPRoute("/pets"))
+
+	span = parseEvent([]byte(albEvent)).span("testFunction", propagation.TraceContext{})
+	assert.Equal(t, "testFunction", span.name)
+	assert.Equal(t, trace.SpanKindServer, span.kind)
+	assert.Contains(t, span.attributes, semconv.FaaSTriggerHTTP)
+	assert.Contains(t, span.attributes, semconv.HTTPClientIP("198.51.100.1"))
+}
+
+func TestMessagingEventSpans(t *testing.T) {
+	wantLink := trace.Link{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+			SpanID:     trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+			TraceFlags: trace.FlagsSampled,
+			Remote:     true,
+		}),
+		Attributes: []attribute.KeyValue{semconv.MessagingMessageID("1")},
+	}
+
+	span := parseEvent([]byte(sqsEvent)).span("testFunction", propagation.TraceContext{})
+	assert.Equal(t, "test-queue process", span.name)
+	assert.Equal(t, trace.SpanKindConsumer, span.kind)
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.FaaSTriggerPubsub,
+		semconv.MessagingSystem("AmazonSQS"),
+		semconv.MessagingOperationProcess,
+		semconv.MessagingBatchMessageCount(2),
+		semconv.MessagingSourceName("test-queue"),
+		semconv.MessagingSourceKindQueue,
+	}, span.attributes)
+	assert.Equal(t, []trace.Link{wantLink}, span.links, "only records propagating a span context are linked")
+
+	span = parseEvent([]byte(snsEvent)).span("testFunction", propagation.TraceContext{})
+	assert.Equal(t, "test-topic process", span.name)
+	assert.Equal(t, trace.SpanKindConsumer, span.kind)
+	assert.Contains(t, span.attributes, semconv.MessagingSystem("AmazonSNS"))
+	assert.Contains(t, span.attributes, semconv.MessagingSourceKindTopic)
+	assert.Equal(t, []trace.Link{wantLink}, span.links)
+
+	span = parseEvent([]byte(kinesisEvent)).span("testFunction", propagation.TraceContext{})
+	assert.Equal(t, "multiple_sources process", span.name)
+	assert.Equal(t, trace.SpanKindConsumer, span.kind)
+	assert.Contains(t, span.attributes, semconv.MessagingSystem("AmazonKinesis"))
+	assert.Contains(t, span.attributes, semconv.MessagingSourceName("multiple_sources"))
+
+	span = parseEvent([]byte(eventBridgeEvent)).span("testFunction", propagation.TraceContext{})
+	assert.Equal(t, "test.source process", span.name)
+	assert.Equal(t, trace.SpanKindConsumer, span.kind)
+	assert.Contains(t, span.attributes, semconv.FaaSTriggerPubsub)
+	assert.Contains(t, span.attributes, semconv.MessagingMessageID("1"))
+}
+
+func TestUnknownEventSpan(t *testing.T) {
+	span := parseEvent([]byte(`{"key": "value"}`)).span("testFunction", propagation.TraceContext{})
+	assert.Equal(t, invocationSpan{name: "testFunction", kind: trace.SpanKindServer}, span)
+}
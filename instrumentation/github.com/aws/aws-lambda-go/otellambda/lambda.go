@@ -16,20 +16,33 @@ package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/aws/aws-lambda-go/lambdacontext"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+
+	// timeoutMargin is how long before the deadline of an invocation its
+	// telemetry is recorded and flushed if the handler has not returned yet.
+	// The execution environment is frozen at the deadline, so the telemetry
+	// of an invocation timing out would otherwise never be exported.
+	timeoutMargin = 100 * time.Millisecond
 )
 
 var errorLogger = log.New(log.Writer(), "OTel Lambda Error: ", 0)
@@ -38,13 +51,31 @@ type instrumentor struct {
 	configuration config
 	resAttrs      []attribute.KeyValue
 	tracer        trace.Tracer
+	instruments   instruments
+	// invoked is set by the first invocation. The instrumentor is created
+	// once per execution environment, so the first invocation is the cold
+	// start of the execution environment.
+	invoked *atomic.Bool
+}
+
+// invocation holds what is needed to end the spans and record the metrics of
+// an invocation once it completes.
+type invocation struct {
+	span  trace.Span
+	start time.Time
+	attrs metric.MeasurementOption
+	// timeout ends the invocation shortly before its deadline, if any.
+	timeout *time.Timer
+	// ended ensures the invocation is ended once, either when the handler
+	// returns or when it times out.
+	ended sync.Once
 }
 
 func newInstrumentor(opts ...Option) instrumentor {
 	cfg := config{
 		TracerProvider: otel.GetTracerProvider(),
+		MeterProvider:  otel.GetMeterProvider(),
 		Flusher:        &noopFlusher{},
-		EventToCarrier: emptyEventToCarrier,
 		Propagator:     otel.GetTextMapPropagator(),
 	}
 	for _, opt := range opts {
@@ -52,20 +83,39 @@ func newInstrumentor(opts ...Option) instrumentor {
 	}
 
 	return instrumentor{configuration: cfg,
-		tracer:   cfg.TracerProvider.Tracer(tracerName, trace.WithInstrumentationVersion(Version())),
-		resAttrs: []attribute.KeyValue{}}
+		tracer:      cfg.TracerProvider.Tracer(tracerName, trace.WithInstrumentationVersion(Version())),
+		instruments: newInstruments(cfg.MeterProvider.Meter(tracerName, metric.WithInstrumentationVersion(Version()))),
+		resAttrs:    []attribute.KeyValue{},
+		invoked:     new(atomic.Bool)}
 }
 
 // Logic to start OTel Tracing.
-func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (context.Context, trace.Span) {
+func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (context.Context, *invocation) {
+	event := parseEvent(eventJSON)
+
 	// Add trace id to context
-	mc := i.configuration.EventToCarrier(eventJSON)
+	var mc propagation.TextMapCarrier
+	if i.configuration.EventToCarrier != nil {
+		mc = i.configuration.EventToCarrier(eventJSON)
+	} else {
+		mc = event.carrier()
+	}
 	ctx = i.configuration.Propagator.Extract(ctx, mc)
 
 	var span trace.Span
-	spanName := os.Getenv("AWS_LAMBDA_FUNCTION_NAME")
+	invocationSpan := event.span(os.Getenv("AWS_LAMBDA_FUNCTION_NAME"), i.configuration.Propagator)
+
+	attributes := invocationSpan.attributes
+	var metricAttrs []attribute.KeyValue
+	for _, attr := range invocationSpan.attributes {
+		if attr.Key == semconv.FaaSTriggerKey {
+			metricAttrs = append(metricAttrs, attr)
+		}
+	}
+	if !i.invoked.Swap(true) {
+		attributes = append(attributes, semconv.FaaSColdstart(true))
+	}
 
-	var attributes []attribute.KeyValue
 	lc, ok := lambdacontext.FromContext(ctx)
 	if !ok {
 		errorLogger.Println("failed to load lambda context from context, ensure tracing enabled in Lambda")
@@ -89,18 +139,81 @@ func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (cont
 		attributes = append(attributes, i.resAttrs...)
 	}
 
-	ctx, span = i.tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...))
+	ctx, span = i.tracer.Start(ctx, invocationSpan.name,
+		trace.WithSpanKind(invocationSpan.kind),
+		trace.WithAttributes(attributes...),
+		trace.WithLinks(invocationSpan.links...),
+	)
 
-	return ctx, span
+	inv := &invocation{
+		span:  span,
+		start: time.Now(),
+		attrs: metric.WithAttributes(metricAttrs...),
+	}
+	// there is no time left to end the invocation before a deadline
+	// closer than timeoutMargin
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) > timeoutMargin {
+		timeoutCtx := ctx
+		inv.timeout = time.AfterFunc(time.Until(deadline)-timeoutMargin, func() {
+			i.end(timeoutCtx, inv, nil, true)
+		})
+	}
+	return ctx, inv
 }
 
 // Logic to wrap up OTel Tracing.
-func (i *instrumentor) tracingEnd(ctx context.Context, span trace.Span) {
-	span.End()
+func (i *instrumentor) tracingEnd(ctx context.Context, inv *invocation, invokeErr error) {
+	if inv.timeout != nil {
+		inv.timeout.Stop()
+	}
+	// a no-op if the invocation already timed out
+	i.end(ctx, inv, invokeErr, errors.Is(ctx.Err(), context.DeadlineExceeded))
+}
+
+// end ends the span of the invocation, records its metrics and flushes its
+// telemetry, unless it was already ended.
+func (i *instrumentor) end(ctx context.Context, inv *invocation, invokeErr error, timedOut bool) {
+	inv.ended.Do(func() {
+		i.endOnce(ctx, inv, invokeErr, timedOut)
+	})
+}
+
+func (i *instrumentor) endOnce(ctx context.Context, inv *invocation, invokeErr error, timedOut bool) {
+	elapsedTime := float64(time.Since(inv.start)) / float64(time.Millisecond)
+	if invokeErr != nil {
+		inv.span.RecordError(invokeErr)
+		inv.span.SetStatus(codes.Error, invokeErr.Error())
+	} else if timedOut {
+		inv.span.SetStatus(codes.Error, "invocation timed out")
+	}
+	inv.span.End()
+
+	// the invocation context is done once its deadline is exceeded, but
+	// the telemetry of the invocation still has to be recorded and flushed
+	metricCtx := withoutCancel(ctx)
+	if timedOut {
+		ctx = metricCtx
+	}
+
+	i.instruments.invocations.Add(metricCtx, 1, inv.attrs)
+	i.instruments.duration.Record(metricCtx, elapsedTime, inv.attrs)
+	if invokeErr != nil || timedOut {
+		i.instruments.errors.Add(metricCtx, 1, inv.attrs)
+	}
+	if timedOut {
+		i.instruments.timeouts.Add(metricCtx, 1, inv.attrs)
+	}
 
 	// force flush any tracing data since lambda may freeze
 	err := i.configuration.Flusher.ForceFlush(ctx)
 	if err != nil {
 		errorLogger.Println("failed to force a flush, lambda may freeze before instrumentation exported: ", err)
 	}
+
+	// force flush metrics as well if the MeterProvider supports it
+	if flusher, ok := i.configuration.MeterProvider.(Flusher); ok {
+		if err := flusher.ForceFlush(ctx); err != nil {
+			errorLogger.Println("failed to force a flush of metrics, lambda may freeze before instrumentation exported: ", err)
+		}
+	}
 }
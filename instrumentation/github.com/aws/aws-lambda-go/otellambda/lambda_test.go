@@ -27,6 +27,8 @@ import (
 	"github.com/aws/aws-lambda-go/lambda/messages"
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel"
 )
 
 var (
@@ -267,6 +269,11 @@ func TestHandlerInvokes(t *testing.T) {
 	}
 }
 
+func TestWithMeterProviderNil(t *testing.T) {
+	i := newInstrumentor(WithMeterProvider(nil))
+	assert.Equal(t, otel.GetMeterProvider(), i.configuration.MeterProvider)
+}
+
 func BenchmarkInstrumentHandler(b *testing.B) {
 	setEnvVars()
 
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otellambda // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Names of the instruments recorded by the instrumentation.
+const (
+	Invocations    = "faas.invocations"     // Number of invocations
+	Errors         = "faas.errors"          // Number of invocations which returned an error or timed out
+	InvokeDuration = "faas.invoke_duration" // Duration of invocations
+	Timeouts       = "faas.timeouts"        // Number of invocations which exceeded their deadline
+)
+
+type instruments struct {
+	invocations metric.Int64Counter
+	errors      metric.Int64Counter
+	duration    metric.Float64Histogram
+	timeouts    metric.Int64Counter
+}
+
+func newInstruments(meter metric.Meter) instruments {
+	var (
+		i   instruments
+		err error
+	)
+
+	i.invocations, err = meter.Int64Counter(
+		Invocations,
+		metric.WithUnit("{invocation}"),
+		metric.WithDescription("Counts the invocations of the function."),
+	)
+	handleErr(err)
+
+	i.errors, err = meter.Int64Counter(
+		Errors,
+		metric.WithUnit("{error}"),
+		metric.WithDescription("Counts the invocations of the function which returned an error or timed out."),
+	)
+	handleErr(err)
+
+	i.duration, err = meter.Float64Histogram(
+		InvokeDuration,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of the invocations of the function."),
+	)
+	handleErr(err)
+
+	i.timeouts, err = meter.Int64Counter(
+		Timeouts,
+		metric.WithUnit("{timeout}"),
+		metric.WithDescription("Counts the invocations of the function which exceeded their deadline."),
+	)
+	handleErr(err)
+
+	return i
+}
+
+func handleErr(err error) {
+	if err != nil {
+		otel.Handle(err)
+	}
+}
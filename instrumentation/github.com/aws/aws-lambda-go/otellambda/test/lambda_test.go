@@ -30,13 +30,17 @@ import (
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	lambdadetector "go.opentelemetry.io/contrib/detectors/aws/lambda"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-lambda-go/otellambda"
 	"go.opentelemetry.io/contrib/propagators/aws/xray"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
@@ -136,7 +140,8 @@ var (
 		SpanKind:  trace.SpanKindServer,
 		StartTime: time.Time{},
 		EndTime:   time.Time{},
-		Attributes: []attribute.KeyValue{attribute.String("faas.execution", "123"),
+		Attributes: []attribute.KeyValue{attribute.Bool("faas.coldstart", true),
+			attribute.String("faas.execution", "123"),
 			attribute.String("faas.id", "arn:partition:service:region:account-id:resource-type:resource-id"),
 			attribute.String("cloud.account.id", "account-id")},
 		Events:            nil,
@@ -326,7 +331,8 @@ var (
 		SpanKind:  trace.SpanKindServer,
 		StartTime: time.Time{},
 		EndTime:   time.Time{},
-		Attributes: []attribute.KeyValue{attribute.String("faas.execution", "123"),
+		Attributes: []attribute.KeyValue{attribute.Bool("faas.coldstart", true),
+			attribute.String("faas.execution", "123"),
 			attribute.String("faas.id", "arn:partition:service:region:account-id:resource-type:resource-id"),
 			attribute.String("cloud.account.id", "account-id")},
 		Events:            nil,
@@ -400,3 +406,211 @@ func TestWrapHandlerTracingWithMockPropagator(t *testing.T) {
 	stub := memExporter.GetSpans()[0]
 	assertStubEqualsIgnoreTime(t, mockPropagatorTestsExpectedSpanStub, stub)
 }
+
+func TestWrapHandlerTracingSQSEvent(t *testing.T) {
+	setEnvVars()
+	tp, memExporter := initMockTracerProvider()
+
+	wrapped := otellambda.WrapHandler(emptyHandler{},
+		otellambda.WithTracerProvider(tp),
+		otellambda.WithPropagator(xray.Propagator{}))
+
+	payload := []byte(`{"Records": [{
+		"messageId": "message-id",
+		"eventSource": "aws:sqs",
+		"eventSourceARN": "arn:aws:sqs:us-east-1:123456789012:test-queue",
+		"attributes": {"AWSTraceHeader": "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1"}
+	}]}`)
+	_, err := wrapped.Invoke(lambdacontext.NewContext(context.Background(), &mockLambdaContext), payload)
+	assert.NoError(t, err)
+
+	spans := memExporter.GetSpans()
+	require.Len(t, spans, 1)
+	batch := spans[0]
+
+	assert.Equal(t, "test-queue process", batch.Name)
+	assert.Equal(t, trace.SpanKindConsumer, batch.SpanKind)
+	assert.False(t, batch.Parent.IsValid())
+	assert.Contains(t, batch.Attributes, semconv.FaaSTriggerPubsub)
+	assert.Contains(t, batch.Attributes, semconv.MessagingSourceName("test-queue"))
+	if assert.Len(t, batch.Links, 1) {
+		assert.Equal(t, expectedTraceID, batch.Links[0].SpanContext.TraceID())
+		assert.Contains(t, batch.Links[0].Attributes, semconv.MessagingMessageID("message-id"))
+	}
+}
+
+func TestWrapHandlerColdStart(t *testing.T) {
+	setEnvVars()
+	tp, memExporter := initMockTracerProvider()
+
+	wrapped := otellambda.WrapHandler(emptyHandler{}, otellambda.WithTracerProvider(tp))
+	for i := 0; i < 2; i++ {
+		_, err := wrapped.Invoke(mockContext, []byte{})
+		assert.NoError(t, err)
+	}
+
+	spans := memExporter.GetSpans()
+	assert.Len(t, spans, 2)
+	assert.Contains(t, spans[0].Attributes, semconv.FaaSColdstart(true))
+	for _, attr := range spans[1].Attributes {
+		assert.NotEqual(t, semconv.FaaSColdstartKey, attr.Key)
+	}
+}
+
+type errorHandler struct{}
+
+func (h errorHandler) Invoke(ctx context.Context, _ []byte) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+var _ lambda.Handler = errorHandler{}
+
+type flushingReader struct {
+	metric.Reader
+	flushCount int
+}
+
+func (r *flushingReader) ForceFlush(ctx context.Context) error {
+	r.flushCount++
+	return r.Reader.ForceFlush(ctx)
+}
+
+func TestWrapHandlerMetrics(t *testing.T) {
+	setEnvVars()
+	tp, _ := initMockTracerProvider()
+	reader := &flushingReader{Reader: metric.NewManualReader()}
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+
+	wrapped := otellambda.WrapHandler(emptyHandler{}, otellambda.WithTracerProvider(tp), otellambda.WithMeterProvider(mp))
+	_, err := wrapped.Invoke(mockContext, []byte(`{"version": "2.0", "requestContext": {"http": {"method": "GET"}}}`))
+	assert.NoError(t, err)
+
+	wrapped = otellambda.WrapHandler(errorHandler{}, otellambda.WithTracerProvider(tp), otellambda.WithMeterProvider(mp))
+	ctx, cancel := context.WithTimeout(mockContext, time.Millisecond)
+	defer cancel()
+	_, err = wrapped.Invoke(ctx, []byte{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.Equal(t, 2, reader.flushCount)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	if !assert.Len(t, rm.ScopeMetrics, 1) {
+		return
+	}
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	httpAttrs := attribute.NewSet(semconv.FaaSTriggerHTTP)
+	invocations, ok := metrics[otellambda.Invocations].Data.(metricdata.Sum[int64])
+	if assert.True(t, ok) && assert.Len(t, invocations.DataPoints, 2) {
+		var triggers []attribute.Set
+		for _, dp := range invocations.DataPoints {
+			assert.Equal(t, int64(1), dp.Value)
+			triggers = append(triggers, dp.Attributes)
+		}
+		assert.ElementsMatch(t, []attribute.Set{httpAttrs, *attribute.EmptySet()}, triggers)
+	}
+
+	duration, ok := metrics[otellambda.InvokeDuration].Data.(metricdata.Histogram[float64])
+	if assert.True(t, ok) && assert.Len(t, duration.DataPoints, 2) {
+		for _, dp := range duration.DataPoints {
+			assert.Equal(t, uint64(1), dp.Count)
+		}
+	}
+
+	errs, ok := metrics[otellambda.Errors].Data.(metricdata.Sum[int64])
+	if assert.True(t, ok) && assert.Len(t, errs.DataPoints, 1) {
+		assert.Equal(t, int64(1), errs.DataPoints[0].Value)
+		assert.Equal(t, 0, errs.DataPoints[0].Attributes.Len())
+	}
+
+	timeouts, ok := metrics[otellambda.Timeouts].Data.(metricdata.Sum[int64])
+	if assert.True(t, ok) && assert.Len(t, timeouts.DataPoints, 1) {
+		assert.Equal(t, int64(1), timeouts.DataPoints[0].Value)
+		assert.Equal(t, 0, timeouts.DataPoints[0].Attributes.Len())
+	}
+}
+
+func TestWrapHandlerErrorStatus(t *testing.T) {
+	setEnvVars()
+	tp, memExporter := initMockTracerProvider()
+
+	// The deadline is too close for the invocation to be ended before it, so
+	// the invocation is ended with the error of the handler.
+	wrapped := otellambda.WrapHandler(errorHandler{}, otellambda.WithTracerProvider(tp))
+	ctx, cancel := context.WithTimeout(mockContext, time.Millisecond)
+	defer cancel()
+	_, err := wrapped.Invoke(ctx, []byte{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	spans := memExporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, sdktrace.Status{Code: codes.Error, Description: err.Error()}, spans[0].Status)
+	if assert.Len(t, spans[0].Events, 1) {
+		assert.Equal(t, semconv.ExceptionEventName, spans[0].Events[0].Name)
+	}
+}
+
+type handlerFunc func(context.Context, []byte) ([]byte, error)
+
+func (f handlerFunc) Invoke(ctx context.Context, payload []byte) ([]byte, error) {
+	return f(ctx, payload)
+}
+
+type notifyingFlusher chan struct{}
+
+func (f notifyingFlusher) ForceFlush(context.Context) error {
+	close(f)
+	return nil
+}
+
+func TestWrapHandlerTimeout(t *testing.T) {
+	setEnvVars()
+	tp, memExporter := initMockTracerProvider()
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	flushed := make(notifyingFlusher)
+
+	// The handler does not return before the telemetry of the invocation
+	// is flushed, which has to happen before its deadline.
+	handler := handlerFunc(func(ctx context.Context, _ []byte) ([]byte, error) {
+		select {
+		case <-flushed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		assert.Len(t, memExporter.GetSpans(), 1, "span ended before the deadline")
+		return nil, nil
+	})
+	wrapped := otellambda.WrapHandler(handler,
+		otellambda.WithTracerProvider(tp),
+		otellambda.WithMeterProvider(mp),
+		otellambda.WithFlusher(flushed))
+	ctx, cancel := context.WithTimeout(mockContext, time.Second)
+	defer cancel()
+	_, err := wrapped.Invoke(ctx, []byte{})
+	require.NoError(t, err, "telemetry not flushed before the deadline")
+
+	// The invocation is not ended again once the handler returns.
+	spans := memExporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, sdktrace.Status{Code: codes.Error, Description: "invocation timed out"}, spans[0].Status)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+	for _, name := range []string{otellambda.Invocations, otellambda.Errors, otellambda.Timeouts} {
+		sum, ok := metrics[name].Data.(metricdata.Sum[int64])
+		if assert.True(t, ok, name) && assert.Len(t, sum.DataPoints, 1, name) {
+			assert.Equal(t, int64(1), sum.DataPoints[0].Value, name)
+		}
+	}
+}
@@ -80,12 +80,7 @@ func (whf *wrappedHandlerFunction) wrapperInternals(ctx context.Context, handler
 	response := wrappedLambdaHandler.Call(argsWrapped)[0].Interface().([]reflect.Value)
 
 	// convert return values into (interface{}, error)
-	var err error
-	if len(response) > 0 {
-		if errVal, ok := response[len(response)-1].Interface().(error); ok {
-			err = errVal
-		}
-	}
+	err := responseError(response)
 	var val interface{}
 	if len(response) > 1 {
 		val = response[0].Interface()
@@ -94,6 +89,16 @@ func (whf *wrappedHandlerFunction) wrapperInternals(ctx context.Context, handler
 	return val, err
 }
 
+// Returns the error returned by the customer handler, if any.
+func responseError(response []reflect.Value) error {
+	if len(response) > 0 {
+		if errVal, ok := response[len(response)-1].Interface().(error); ok {
+			return errVal
+		}
+	}
+	return nil
+}
+
 // InstrumentHandler Provides a lambda handler which wraps customer lambda handler with OTel Tracing.
 func InstrumentHandler(handlerFunc interface{}, options ...Option) interface{} {
 	whf := wrappedHandlerFunction{instrumentor: newInstrumentor(options...)}
@@ -151,8 +156,9 @@ func InstrumentHandler(handlerFunc interface{}, options ...Option) interface{} {
 // Adds OTel span surrounding customer handler call.
 func (whf *wrappedHandlerFunction) wrapper(handlerFunc interface{}) func(ctx context.Context, eventJSON []byte, event interface{}, takesContext bool) []reflect.Value {
 	return func(ctx context.Context, eventJSON []byte, event interface{}, takesContext bool) []reflect.Value {
-		ctx, span := whf.instrumentor.tracingBegin(ctx, eventJSON)
-		defer whf.instrumentor.tracingEnd(ctx, span)
+		ctx, inv := whf.instrumentor.tracingBegin(ctx, eventJSON)
+		var response []reflect.Value
+		defer func() { whf.instrumentor.tracingEnd(ctx, inv, responseError(response)) }()
 
 		handler := reflect.ValueOf(handlerFunc)
 		var args []reflect.Value
@@ -163,7 +169,7 @@ func (whf *wrappedHandlerFunction) wrapper(handlerFunc interface{}) func(ctx con
 			args = append(args, reflect.ValueOf(event))
 		}
 
-		response := handler.Call(args)
+		response = handler.Call(args)
 
 		return response
 	}
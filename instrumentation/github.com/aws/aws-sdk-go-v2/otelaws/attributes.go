@@ -19,6 +19,11 @@ import (
 
 	v2Middleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/smithy-go/middleware"
 
@@ -30,12 +35,20 @@ import (
 const (
 	RegionKey    attribute.Key = "aws.region"
 	RequestIDKey attribute.Key = "aws.request_id"
+	AttemptKey   attribute.Key = "aws.attempt"
+	ErrorCodeKey attribute.Key = "aws.error_code"
+	ThrottledKey attribute.Key = "aws.throttled"
 	AWSSystemVal string        = "aws-api"
 )
 
 var servicemap = map[string]AttributeSetter{
-	dynamodb.ServiceID: DynamoDBAttributeSetter,
-	sqs.ServiceID:      SQSAttributeSetter,
+	dynamodb.ServiceID:    DynamoDBAttributeSetter,
+	eventbridge.ServiceID: EventBridgeAttributeSetter,
+	kinesis.ServiceID:     KinesisAttributeSetter,
+	lambda.ServiceID:      LambdaAttributeSetter,
+	s3.ServiceID:          S3AttributeSetter,
+	sns.ServiceID:         SNSAttributeSetter,
+	sqs.ServiceID:         SQSAttributeSetter,
 }
 
 // SystemAttr return the AWS RPC system attribute.
@@ -63,6 +76,17 @@ func RequestIDAttr(requestID string) attribute.KeyValue {
 	return RequestIDKey.String(requestID)
 }
 
+// AttemptAttr returns the attribute of the number of an attempt of an AWS
+// operation, starting at 1.
+func AttemptAttr(attempt int) attribute.KeyValue {
+	return AttemptKey.Int(attempt)
+}
+
+// ErrorCodeAttr returns the AWS error code attribute.
+func ErrorCodeAttr(code string) attribute.KeyValue {
+	return ErrorCodeKey.String(code)
+}
+
 // DefaultAttributeSetter checks to see if there are service specific attributes available to set for the AWS service.
 // If there are service specific attributes available then they will be included.
 func DefaultAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
@@ -16,15 +16,19 @@ package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.co
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	v2Middleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/middleware"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
@@ -36,6 +40,10 @@ const (
 
 type spanTimestampKey struct{}
 
+// attemptCountKey is the context key of the number of attempts of an
+// operation.
+type attemptCountKey struct{}
+
 // AttributeSetter returns an array of KeyValue pairs, it can be used to set custom attributes.
 type AttributeSetter func(context.Context, middleware.InitializeInput) []attribute.KeyValue
 
@@ -43,6 +51,7 @@ type otelMiddlewares struct {
 	tracer          trace.Tracer
 	propagator      propagation.TextMapPropagator
 	attributeSetter []AttributeSetter
+	instruments     instruments
 }
 
 func (m otelMiddlewares) initializeMiddlewareBefore(stack *middleware.Stack) error {
@@ -61,36 +70,110 @@ func (m otelMiddlewares) initializeMiddlewareAfter(stack *middleware.Stack) erro
 		out middleware.InitializeOutput, metadata middleware.Metadata, err error) {
 		serviceID := v2Middleware.GetServiceID(ctx)
 		operation := v2Middleware.GetOperationName(ctx)
-		region := v2Middleware.GetRegion(ctx)
+		start := ctx.Value(spanTimestampKey{}).(time.Time)
 
-		attributes := []attribute.KeyValue{
-			SystemAttr(),
-			ServiceAttr(serviceID),
-			RegionAttr(region),
-			OperationAttr(operation),
-		}
+		attributes := operationAttrs(ctx)
 		for _, setter := range m.attributeSetter {
 			attributes = append(attributes, setter(ctx, in)...)
 		}
 
 		ctx, span := m.tracer.Start(ctx, spanName(serviceID, operation),
-			trace.WithTimestamp(ctx.Value(spanTimestampKey{}).(time.Time)),
+			trace.WithTimestamp(start),
 			trace.WithSpanKind(trace.SpanKindClient),
 			trace.WithAttributes(attributes...),
 		)
 		defer span.End()
 
+		// Propagate the Trace information in the messages, events and
+		// invocations of the services that do not forward the HTTP headers to
+		// their receivers.
+		in.Parameters = injectTraceContext(ctx, m.propagator, in.Parameters)
+
+		ctx = context.WithValue(ctx, attemptCountKey{}, new(int))
 		out, metadata, err = next.HandleInitialize(ctx, in)
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
 		}
 
+		metricAttrs := operationAttrs(ctx)
+		if code := errorCode(err); code != "" {
+			metricAttrs = append(metricAttrs, ErrorCodeAttr(code))
+		}
+		elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+		m.instruments.duration.Record(withoutCancel(ctx), elapsed, metric.WithAttributes(metricAttrs...))
+
 		return out, metadata, err
 	}),
 		middleware.After)
 }
 
+// retryAttemptMiddleware adds an event to the span of the operation and
+// records the attempt metrics for every attempt of the operation. It is
+// inserted after the retry middleware of the SDK, if the stack has one, so it
+// is called for every attempt.
+func (m otelMiddlewares) retryAttemptMiddleware(stack *middleware.Stack) error {
+	if _, ok := stack.Finalize.Get((*retry.Attempt)(nil).ID()); !ok {
+		return nil
+	}
+	return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("OTelRetryAttemptMiddleware", func(
+		ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+		out middleware.FinalizeOutput, metadata middleware.Metadata, err error) {
+		attempt := 1
+		if n, ok := ctx.Value(attemptCountKey{}).(*int); ok {
+			*n++
+			attempt = *n
+		}
+
+		out, metadata, err = next.HandleFinalize(ctx, in)
+
+		eventAttrs := []attribute.KeyValue{AttemptAttr(attempt)}
+		metricAttrs := operationAttrs(ctx)
+		if code := errorCode(err); code != "" {
+			eventAttrs = append(eventAttrs, ErrorCodeAttr(code))
+			metricAttrs = append(metricAttrs, ErrorCodeAttr(code))
+		}
+		ctx, o := withoutCancel(ctx), metric.WithAttributes(metricAttrs...)
+		if isThrottle(err) {
+			eventAttrs = append(eventAttrs, ThrottledKey.Bool(true))
+			m.instruments.throttles.Add(ctx, 1, o)
+		}
+		m.instruments.attempts.Add(ctx, 1, o)
+		trace.SpanFromContext(ctx).AddEvent("attempt", trace.WithAttributes(eventAttrs...))
+
+		return out, metadata, err
+	}),
+		(*retry.Attempt)(nil).ID(), middleware.After)
+}
+
+// operationAttrs returns the attributes of the operation of ctx common to its
+// span and metrics.
+func operationAttrs(ctx context.Context) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		SystemAttr(),
+		ServiceAttr(v2Middleware.GetServiceID(ctx)),
+		RegionAttr(v2Middleware.GetRegion(ctx)),
+		OperationAttr(v2Middleware.GetOperationName(ctx)),
+	}
+}
+
+// errorCode returns the error code of the API error wrapped by err, if any.
+func errorCode(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	return ""
+}
+
+// isThrottle returns if err is considered a throttle error by the SDK.
+func isThrottle(err error) bool {
+	if err == nil {
+		return false
+	}
+	return retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == aws.TrueTernary
+}
+
 func (m otelMiddlewares) deserializeMiddleware(stack *middleware.Stack) error {
 	return stack.Deserialize.Add(middleware.DeserializeMiddlewareFunc("OTelDeserializeMiddleware", func(
 		ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (
@@ -143,21 +226,14 @@ func spanName(serviceID, operation string) string {
 // OTel middlewares can be appended to either all aws clients or a specific operation.
 // Please see more details in https://aws.github.io/aws-sdk-go-v2/docs/middleware/
 func AppendMiddlewares(apiOptions *[]func(*middleware.Stack) error, opts ...Option) {
-	cfg := config{
-		TracerProvider:    otel.GetTracerProvider(),
-		TextMapPropagator: otel.GetTextMapPropagator(),
-	}
-	for _, opt := range opts {
-		opt.apply(&cfg)
-	}
-
+	cfg := newConfig(opts...)
 	if cfg.AttributeSetter == nil {
 		cfg.AttributeSetter = []AttributeSetter{DefaultAttributeSetter}
 	}
 
-	m := otelMiddlewares{tracer: cfg.TracerProvider.Tracer(tracerName,
-		trace.WithInstrumentationVersion(Version())),
+	m := otelMiddlewares{tracer: cfg.tracer(),
 		propagator:      cfg.TextMapPropagator,
-		attributeSetter: cfg.AttributeSetter}
-	*apiOptions = append(*apiOptions, m.initializeMiddlewareBefore, m.initializeMiddlewareAfter, m.finalizeMiddleware, m.deserializeMiddleware)
+		attributeSetter: cfg.AttributeSetter,
+		instruments:     newInstruments(cfg.meter())}
+	*apiOptions = append(*apiOptions, m.initializeMiddlewareBefore, m.initializeMiddlewareAfter, m.finalizeMiddleware, m.retryAttemptMiddleware, m.deserializeMiddleware)
 }
@@ -15,16 +15,40 @@
 package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 
 import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type config struct {
 	TracerProvider    trace.TracerProvider
+	MeterProvider     metric.MeterProvider
 	TextMapPropagator propagation.TextMapPropagator
 	AttributeSetter   []AttributeSetter
 }
 
+// newConfig returns a config with all Options set.
+func newConfig(opts ...Option) config {
+	cfg := config{
+		TracerProvider:    otel.GetTracerProvider(),
+		MeterProvider:     otel.GetMeterProvider(),
+		TextMapPropagator: otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	return cfg
+}
+
+func (c config) tracer() trace.Tracer {
+	return c.TracerProvider.Tracer(tracerName, trace.WithInstrumentationVersion(Version()))
+}
+
+func (c config) meter() metric.Meter {
+	return c.MeterProvider.Meter(tracerName, metric.WithInstrumentationVersion(Version()))
+}
+
 // Option applies an option value.
 type Option interface {
 	apply(*config)
@@ -48,6 +72,16 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global MeterProvider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithTextMapPropagator specifies a Text Map Propagator to use when propagating context.
 // If none is specified, the global TextMapPropagator is used.
 func WithTextMapPropagator(propagator propagation.TextMapPropagator) Option {
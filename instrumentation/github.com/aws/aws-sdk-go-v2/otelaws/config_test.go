@@ -31,3 +31,13 @@ func TestWithTextMapPropagator(t *testing.T) {
 
 	assert.Equal(t, cfg.TextMapPropagator, propagator)
 }
+
+func TestWithMeterProvider(t *testing.T) {
+	cfg := config{}
+	provider := otel.GetMeterProvider()
+
+	option := WithMeterProvider(provider)
+	option.apply(&cfg)
+
+	assert.Equal(t, cfg.MeterProvider, provider)
+}
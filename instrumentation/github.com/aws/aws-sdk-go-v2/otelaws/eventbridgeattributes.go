@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// EventBridgeEventBusNamesKey is the attribute key of the names of the event
+// buses events are sent to. The default event bus is named "default".
+const EventBridgeEventBusNamesKey attribute.Key = "aws.eventbridge.event_bus_names"
+
+// EventBridgeAttributeSetter sets EventBridge specific attributes depending on the EventBridge operation being performed.
+func EventBridgeAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+	var eventBridgeAttributes []attribute.KeyValue
+
+	switch v := in.Parameters.(type) {
+	case *eventbridge.PutEventsInput:
+		var names []string
+		seen := make(map[string]bool)
+		for _, entry := range v.Entries {
+			name := "default"
+			if entry.EventBusName != nil && *entry.EventBusName != "" {
+				name = *entry.EventBusName
+			}
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+		eventBridgeAttributes = append(eventBridgeAttributes,
+			semconv.MessagingOperationPublish,
+			semconv.MessagingBatchMessageCount(len(v.Entries)),
+			EventBridgeEventBusNamesKey.StringSlice(names),
+		)
+	}
+
+	return eventBridgeAttributes
+}
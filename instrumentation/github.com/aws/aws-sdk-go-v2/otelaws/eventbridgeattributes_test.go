@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+func TestEventBridgePutEventsInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &eventbridge.PutEventsInput{
+			Entries: []types.PutEventsRequestEntry{
+				{EventBusName: aws.String("test-bus")},
+				{},
+				{EventBusName: aws.String("test-bus")},
+			},
+		},
+	}
+
+	attributes := EventBridgeAttributeSetter(context.TODO(), input)
+
+	assert.Contains(t, attributes, semconv.MessagingOperationPublish)
+	assert.Contains(t, attributes, semconv.MessagingBatchMessageCount(3))
+	assert.Contains(t, attributes, EventBridgeEventBusNamesKey.StringSlice([]string{"test-bus", "default"}))
+}
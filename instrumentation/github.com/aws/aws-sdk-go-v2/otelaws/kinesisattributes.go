@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Kinesis attributes.
+const (
+	KinesisStreamNameKey  attribute.Key = "aws.kinesis.stream_name"
+	KinesisStreamARNKey   attribute.Key = "aws.kinesis.stream_arn"
+	KinesisConsumerARNKey attribute.Key = "aws.kinesis.consumer_arn"
+	KinesisShardIDKey     attribute.Key = "aws.kinesis.shard_id"
+)
+
+// KinesisAttributeSetter sets Kinesis specific attributes depending on the Kinesis operation being performed.
+func KinesisAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+	var (
+		kinesisAttributes          []attribute.KeyValue
+		name, arn, consumer, shard *string
+	)
+
+	switch v := in.Parameters.(type) {
+	case *kinesis.PutRecordInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.PutRecordsInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.GetRecordsInput:
+		arn = v.StreamARN
+	case *kinesis.GetShardIteratorInput:
+		name, arn, shard = v.StreamName, v.StreamARN, v.ShardId
+	case *kinesis.DescribeStreamInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.DescribeStreamSummaryInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.ListShardsInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.SubscribeToShardInput:
+		consumer, shard = v.ConsumerARN, v.ShardId
+	}
+
+	if name != nil {
+		kinesisAttributes = append(kinesisAttributes, KinesisStreamNameKey.String(*name))
+	}
+	if arn != nil {
+		kinesisAttributes = append(kinesisAttributes, KinesisStreamARNKey.String(*arn))
+	}
+	if consumer != nil {
+		kinesisAttributes = append(kinesisAttributes, KinesisConsumerARNKey.String(*consumer))
+	}
+	if shard != nil {
+		kinesisAttributes = append(kinesisAttributes, KinesisShardIDKey.String(*shard))
+	}
+
+	return kinesisAttributes
+}
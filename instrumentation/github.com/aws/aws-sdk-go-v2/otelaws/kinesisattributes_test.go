@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKinesisPutRecordInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &kinesis.PutRecordInput{
+			StreamName: aws.String("test-stream"),
+		},
+	}
+
+	attributes := KinesisAttributeSetter(context.TODO(), input)
+
+	assert.Contains(t, attributes, KinesisStreamNameKey.String("test-stream"))
+}
+
+func TestKinesisGetShardIteratorInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &kinesis.GetShardIteratorInput{
+			StreamARN: aws.String("arn:aws:kinesis:us-east-1:123456789012:stream/test-stream"),
+			ShardId:   aws.String("shardId-000000000000"),
+		},
+	}
+
+	attributes := KinesisAttributeSetter(context.TODO(), input)
+
+	assert.Contains(t, attributes, KinesisStreamARNKey.String("arn:aws:kinesis:us-east-1:123456789012:stream/test-stream"))
+	assert.Contains(t, attributes, KinesisShardIDKey.String("shardId-000000000000"))
+}
+
+func TestKinesisSubscribeToShardInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &kinesis.SubscribeToShardInput{
+			ConsumerARN: aws.String("arn:aws:kinesis:us-east-1:123456789012:stream/test-stream/consumer/test-consumer:1"),
+			ShardId:     aws.String("shardId-000000000000"),
+		},
+	}
+
+	attributes := KinesisAttributeSetter(context.TODO(), input)
+
+	assert.Contains(t, attributes, KinesisConsumerARNKey.String("arn:aws:kinesis:us-east-1:123456789012:stream/test-stream/consumer/test-consumer:1"))
+	assert.Contains(t, attributes, KinesisShardIDKey.String("shardId-000000000000"))
+	for _, kv := range attributes {
+		assert.NotEqual(t, KinesisStreamARNKey, kv.Key, "a consumer ARN is not a stream ARN")
+	}
+}
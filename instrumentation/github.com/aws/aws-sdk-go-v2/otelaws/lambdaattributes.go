@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+
+	v2Middleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// LambdaAttributeSetter sets Lambda specific attributes depending on the Lambda operation being performed.
+func LambdaAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+	var lambdaAttributes []attribute.KeyValue
+
+	switch v := in.Parameters.(type) {
+	case *lambda.InvokeInput:
+		lambdaAttributes = append(lambdaAttributes,
+			semconv.FaaSInvokedProviderAWS,
+			semconv.FaaSInvokedRegion(v2Middleware.GetRegion(ctx)),
+		)
+		if v.FunctionName != nil {
+			lambdaAttributes = append(lambdaAttributes, semconv.FaaSInvokedName(*v.FunctionName))
+		}
+	}
+
+	return lambdaAttributes
+}
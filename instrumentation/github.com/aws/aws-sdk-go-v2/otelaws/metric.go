@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Names of the instruments recorded by the middlewares.
+const (
+	OperationDuration  = "aws.sdk.duration"  // Operation duration, including all its attempts
+	OperationAttempts  = "aws.sdk.attempts"  // Attempts of operations, including retries
+	OperationThrottles = "aws.sdk.throttles" // Attempts of operations rejected due to throttling
+)
+
+type instruments struct {
+	duration  metric.Float64Histogram
+	attempts  metric.Int64Counter
+	throttles metric.Int64Counter
+}
+
+func newInstruments(meter metric.Meter) instruments {
+	var (
+		i   instruments
+		err error
+	)
+
+	i.duration, err = meter.Float64Histogram(
+		OperationDuration,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of AWS SDK operations, including retries and backoff delays."),
+	)
+	handleErr(err)
+
+	i.attempts, err = meter.Int64Counter(
+		OperationAttempts,
+		metric.WithUnit("{attempt}"),
+		metric.WithDescription("Counts the attempts of AWS SDK operations."),
+	)
+	handleErr(err)
+
+	i.throttles, err = meter.Int64Counter(
+		OperationThrottles,
+		metric.WithUnit("{attempt}"),
+		metric.WithDescription("Counts the attempts of AWS SDK operations that were throttled."),
+	)
+	handleErr(err)
+
+	return i
+}
+
+func handleErr(err error) {
+	if err != nil {
+		otel.Handle(err)
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	ebtypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// messageAttributesLimit is the maximum number of message attributes of
+	// an SQS or SNS message.
+	messageAttributesLimit = 10
+
+	// lambdaClientContextLimit is the maximum size of the base64-encoded
+	// client context of a Lambda invocation.
+	lambdaClientContextLimit = 3583
+
+	// xrayTraceHeader is the field of the AWS X-Ray propagator, the only trace
+	// header supported by EventBridge.
+	xrayTraceHeader = "X-Amzn-Trace-Id"
+)
+
+// injectTraceContext returns a copy of the parameters of the operations
+// carrying a trace context to the receivers of their messages, events or
+// invocations with the span context of ctx injected. The parameters of other
+// operations are returned unchanged.
+//
+// The trace context is injected into:
+//   - the message attributes of the messages sent by SQS SendMessage and
+//     SendMessageBatch, see injectSQSMessageAttributes,
+//   - the message attributes of the messages published by SNS Publish and
+//     PublishBatch,
+//   - the trace header of the events sent by EventBridge PutEvents, if the
+//     propagator is an AWS X-Ray propagator,
+//   - the custom client context of Lambda Invoke.
+func injectTraceContext(ctx context.Context, propagator propagation.TextMapPropagator, params interface{}) interface{} {
+	switch v := params.(type) {
+	case *sns.PublishInput:
+		in := *v
+		in.MessageAttributes = injectMessageAttributes(ctx, propagator, v.MessageAttributes, snsStringValue)
+		return &in
+	case *sns.PublishBatchInput:
+		in := *v
+		in.PublishBatchRequestEntries = make([]snstypes.PublishBatchRequestEntry, len(v.PublishBatchRequestEntries))
+		for i, entry := range v.PublishBatchRequestEntries {
+			entry.MessageAttributes = injectMessageAttributes(ctx, propagator, entry.MessageAttributes, snsStringValue)
+			in.PublishBatchRequestEntries[i] = entry
+		}
+		return &in
+	case *eventbridge.PutEventsInput:
+		header := xrayHeader(ctx, propagator)
+		if header == "" {
+			return v
+		}
+		in := *v
+		in.Entries = make([]ebtypes.PutEventsRequestEntry, len(v.Entries))
+		for i, entry := range v.Entries {
+			if entry.TraceHeader == nil {
+				entry.TraceHeader = aws.String(header)
+			}
+			in.Entries[i] = entry
+		}
+		return &in
+	case *lambda.InvokeInput:
+		clientContext, ok := injectClientContext(ctx, propagator, aws.ToString(v.ClientContext))
+		if !ok {
+			return v
+		}
+		in := *v
+		in.ClientContext = aws.String(clientContext)
+		return &in
+	}
+	return injectSQSMessageAttributes(ctx, propagator, params)
+}
+
+// injectMessageAttributes returns a copy of attrs with the span context of
+// ctx injected as values created by newValue. The fields of propagator are
+// injected in order as long as the message has less than
+// messageAttributesLimit attributes, the others are dropped.
+func injectMessageAttributes[V any](ctx context.Context, propagator propagation.TextMapPropagator, attrs map[string]V, newValue func(string) V) map[string]V {
+	fields := propagation.MapCarrier{}
+	propagator.Inject(ctx, fields)
+	if len(fields) == 0 {
+		return attrs
+	}
+
+	injected := make(map[string]V, len(attrs)+len(fields))
+	for k, v := range attrs {
+		injected[k] = v
+	}
+	for _, k := range orderedKeys(propagator.Fields(), fields) {
+		if _, ok := injected[k]; !ok && len(injected) >= messageAttributesLimit {
+			continue
+		}
+		injected[k] = newValue(fields[k])
+	}
+	return injected
+}
+
+// orderedKeys returns the keys of fields in the order of the propagator
+// fields, followed by the keys that are not propagator fields in
+// lexicographic order.
+func orderedKeys(propagatorFields []string, fields propagation.MapCarrier) []string {
+	keys := make([]string, 0, len(fields))
+	known := make(map[string]bool, len(propagatorFields))
+	for _, k := range propagatorFields {
+		known[k] = true
+		if _, ok := fields[k]; ok {
+			keys = append(keys, k)
+		}
+	}
+	var others []string
+	for k := range fields {
+		if !known[k] {
+			others = append(others, k)
+		}
+	}
+	sort.Strings(others)
+	return append(keys, others...)
+}
+
+// SNSMessageAttributeCarrier adapts the message attributes of an SNS message
+// to satisfy the TextMapCarrier interface.
+type SNSMessageAttributeCarrier map[string]snstypes.MessageAttributeValue
+
+var _ propagation.TextMapCarrier = SNSMessageAttributeCarrier{}
+
+// Get returns the value of the String message attribute associated with the
+// passed key.
+func (c SNSMessageAttributeCarrier) Get(key string) string {
+	v, ok := c[key]
+	if !ok || v.StringValue == nil {
+		return ""
+	}
+	return *v.StringValue
+}
+
+// Set stores the key-value pair as a String message attribute.
+func (c SNSMessageAttributeCarrier) Set(key, value string) {
+	c[key] = snsStringValue(value)
+}
+
+// Keys lists the keys of the message attributes.
+func (c SNSMessageAttributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func snsStringValue(value string) snstypes.MessageAttributeValue {
+	return snstypes.MessageAttributeValue{
+		DataType:    aws.String("String"),
+		StringValue: aws.String(value),
+	}
+}
+
+// xrayHeader returns the AWS X-Ray trace header of the span context of ctx
+// injected by propagator, if it supports it.
+func xrayHeader(ctx context.Context, propagator propagation.TextMapPropagator) string {
+	fields := propagation.MapCarrier{}
+	propagator.Inject(ctx, fields)
+	for k, v := range fields {
+		if strings.EqualFold(k, xrayTraceHeader) {
+			return v
+		}
+	}
+	return ""
+}
+
+// lambdaClientContext is the client context of a Lambda invocation. The
+// trace context is injected into its custom values, which are available to
+// the Lambda function, e.g. in the ClientContext.Custom field of
+// github.com/aws/aws-lambda-go/lambdacontext.
+type lambdaClientContext struct {
+	Client json.RawMessage   `json:"client,omitempty"`
+	Custom map[string]string `json:"custom,omitempty"`
+	Env    json.RawMessage   `json:"env,omitempty"`
+}
+
+// injectClientContext returns the base64-encoded Lambda client context
+// clientContext with the span context of ctx injected into its custom values.
+// It returns false if nothing is injected, either because clientContext is
+// not a valid client context or because the result exceeds the size limit.
+func injectClientContext(ctx context.Context, propagator propagation.TextMapPropagator, clientContext string) (string, bool) {
+	fields := propagation.MapCarrier{}
+	propagator.Inject(ctx, fields)
+	if len(fields) == 0 {
+		return "", false
+	}
+
+	var cc lambdaClientContext
+	if clientContext != "" {
+		b, err := base64.StdEncoding.DecodeString(clientContext)
+		if err != nil {
+			return "", false
+		}
+		if err := json.Unmarshal(b, &cc); err != nil {
+			return "", false
+		}
+	}
+	if cc.Custom == nil {
+		cc.Custom = make(map[string]string, len(fields))
+	}
+	for k, v := range fields {
+		cc.Custom[k] = v
+	}
+
+	b, err := json.Marshal(cc)
+	if err != nil {
+		return "", false
+	}
+	encoded := base64.StdEncoding.EncodeToString(b)
+	if len(encoded) > lambdaClientContextLimit {
+		return "", false
+	}
+	return encoded, true
+}
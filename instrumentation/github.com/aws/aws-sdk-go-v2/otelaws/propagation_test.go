@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	ebtypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestSNSMessageAttributeCarrier(t *testing.T) {
+	carrier := SNSMessageAttributeCarrier{}
+	carrier.Set("key", "value")
+
+	assert.Equal(t, "value", carrier.Get("key"))
+	assert.Equal(t, "", carrier.Get("missing"))
+	assert.Equal(t, []string{"key"}, carrier.Keys())
+	assert.Equal(t, "String", *carrier["key"].DataType)
+}
+
+func TestInjectSNSPublishInput(t *testing.T) {
+	in := &sns.PublishInput{
+		TopicArn: aws.String("test-topic-arn"),
+		Message:  aws.String("message"),
+	}
+
+	got, ok := injectTraceContext(sqsTestContext, propagation.TraceContext{}, in).(*sns.PublishInput)
+	require.True(t, ok)
+
+	assert.Nil(t, in.MessageAttributes, "input of the caller modified")
+	assert.Equal(t,
+		"00-01000000000000000000000000000000-0100000000000000-01",
+		SNSMessageAttributeCarrier(got.MessageAttributes).Get("traceparent"),
+	)
+}
+
+func TestInjectSNSPublishBatchInput(t *testing.T) {
+	in := &sns.PublishBatchInput{
+		TopicArn: aws.String("test-topic-arn"),
+		PublishBatchRequestEntries: []snstypes.PublishBatchRequestEntry{
+			{Id: aws.String("1")},
+			{Id: aws.String("2")},
+		},
+	}
+
+	got, ok := injectTraceContext(sqsTestContext, propagation.TraceContext{}, in).(*sns.PublishBatchInput)
+	require.True(t, ok)
+
+	assert.Nil(t, in.PublishBatchRequestEntries[0].MessageAttributes, "input of the caller modified")
+	require.Len(t, got.PublishBatchRequestEntries, 2)
+	for _, entry := range got.PublishBatchRequestEntries {
+		assert.NotEmpty(t, SNSMessageAttributeCarrier(entry.MessageAttributes).Get("traceparent"))
+	}
+}
+
+func TestInjectEventBridgePutEventsInput(t *testing.T) {
+	in := &eventbridge.PutEventsInput{
+		Entries: []ebtypes.PutEventsRequestEntry{
+			{Detail: aws.String("{}")},
+			{Detail: aws.String("{}"), TraceHeader: aws.String("Root=1-5759e988-bd862e3fe1be46a994272793")},
+		},
+	}
+
+	// EventBridge only carries the AWS X-Ray trace header.
+	assert.Same(t, in, injectTraceContext(sqsTestContext, propagation.TraceContext{}, in))
+
+	prop := mockPropagator{injectKey: xrayTraceHeader, injectValue: "Root=1-00000001-000000000000000000000001"}
+	got, ok := injectTraceContext(sqsTestContext, prop, in).(*eventbridge.PutEventsInput)
+	require.True(t, ok)
+
+	assert.Nil(t, in.Entries[0].TraceHeader, "input of the caller modified")
+	assert.Equal(t, "Root=1-00000001-000000000000000000000001", aws.ToString(got.Entries[0].TraceHeader))
+	assert.Equal(t, "Root=1-5759e988-bd862e3fe1be46a994272793", aws.ToString(got.Entries[1].TraceHeader))
+}
+
+func decodeClientContext(t *testing.T, clientContext *string) lambdaClientContext {
+	b, err := base64.StdEncoding.DecodeString(aws.ToString(clientContext))
+	require.NoError(t, err)
+	var cc lambdaClientContext
+	require.NoError(t, json.Unmarshal(b, &cc))
+	return cc
+}
+
+func TestInjectLambdaInvokeInput(t *testing.T) {
+	clientContext := base64.StdEncoding.EncodeToString([]byte(`{"custom":{"key":"value"},"env":{"platform":"test"}}`))
+	in := &lambda.InvokeInput{
+		FunctionName:  aws.String("test-function"),
+		ClientContext: aws.String(clientContext),
+	}
+
+	got, ok := injectTraceContext(sqsTestContext, propagation.TraceContext{}, in).(*lambda.InvokeInput)
+	require.True(t, ok)
+
+	assert.Equal(t, clientContext, aws.ToString(in.ClientContext), "input of the caller modified")
+	cc := decodeClientContext(t, got.ClientContext)
+	assert.Equal(t, map[string]string{
+		"key":         "value",
+		"traceparent": "00-01000000000000000000000000000000-0100000000000000-01",
+	}, cc.Custom)
+	assert.JSONEq(t, `{"platform":"test"}`, string(cc.Env))
+}
+
+func TestInjectLambdaInvokeInputLimit(t *testing.T) {
+	clientContext := base64.StdEncoding.EncodeToString([]byte(`{"custom":{"key":"` + strings.Repeat("a", 2600) + `"}}`))
+	in := &lambda.InvokeInput{ClientContext: aws.String(clientContext)}
+	assert.Same(t, in, injectTraceContext(sqsTestContext, propagation.TraceContext{}, in))
+
+	in = &lambda.InvokeInput{ClientContext: aws.String("not a client context")}
+	assert.Same(t, in, injectTraceContext(sqsTestContext, propagation.TraceContext{}, in))
+}
+
+func TestInjectTraceContextSQS(t *testing.T) {
+	in := &sqs.SendMessageInput{QueueUrl: aws.String("test-queue-url")}
+
+	got, ok := injectTraceContext(sqsTestContext, propagation.TraceContext{}, in).(*sqs.SendMessageInput)
+	require.True(t, ok)
+	assert.NotEmpty(t, SQSMessageAttributeCarrier(got.MessageAttributes).Get("traceparent"))
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// S3 attributes.
+const (
+	S3BucketKey     attribute.Key = "aws.s3.bucket"
+	S3KeyKey        attribute.Key = "aws.s3.key"
+	S3CopySourceKey attribute.Key = "aws.s3.copy_source"
+	S3UploadIDKey   attribute.Key = "aws.s3.upload_id"
+	S3PartNumberKey attribute.Key = "aws.s3.part_number"
+)
+
+// S3AttributeSetter sets S3 specific attributes depending on the S3 operation being performed.
+func S3AttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+	var s3Attributes []attribute.KeyValue
+
+	switch v := in.Parameters.(type) {
+	case *s3.GetObjectInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, v.Key)...)
+	case *s3.HeadObjectInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, v.Key)...)
+	case *s3.PutObjectInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, v.Key)...)
+	case *s3.DeleteObjectInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, v.Key)...)
+	case *s3.GetObjectAttributesInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, v.Key)...)
+	case *s3.CopyObjectInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, v.Key)...)
+		if v.CopySource != nil {
+			s3Attributes = append(s3Attributes, S3CopySourceKey.String(*v.CopySource))
+		}
+	case *s3.CreateMultipartUploadInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, v.Key)...)
+	case *s3.UploadPartInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, v.Key)...)
+		s3Attributes = append(s3Attributes,
+			S3UploadIDKey.String(aws.ToString(v.UploadId)),
+			S3PartNumberKey.Int(int(v.PartNumber)),
+		)
+	case *s3.CompleteMultipartUploadInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, v.Key)...)
+		s3Attributes = append(s3Attributes, S3UploadIDKey.String(aws.ToString(v.UploadId)))
+	case *s3.AbortMultipartUploadInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, v.Key)...)
+		s3Attributes = append(s3Attributes, S3UploadIDKey.String(aws.ToString(v.UploadId)))
+	case *s3.DeleteObjectsInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, nil)...)
+	case *s3.ListObjectsInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, nil)...)
+	case *s3.ListObjectsV2Input:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, nil)...)
+	case *s3.ListMultipartUploadsInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, nil)...)
+	case *s3.CreateBucketInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, nil)...)
+	case *s3.DeleteBucketInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, nil)...)
+	case *s3.HeadBucketInput:
+		s3Attributes = append(s3Attributes, s3ObjectAttrs(v.Bucket, nil)...)
+	}
+
+	return s3Attributes
+}
+
+// s3ObjectAttrs returns the attributes of the bucket and the key of an
+// object, skipping the nil ones.
+func s3ObjectAttrs(bucket, key *string) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if bucket != nil {
+		attrs = append(attrs, S3BucketKey.String(*bucket))
+	}
+	if key != nil {
+		attrs = append(attrs, S3KeyKey.String(*key))
+	}
+	return attrs
+}
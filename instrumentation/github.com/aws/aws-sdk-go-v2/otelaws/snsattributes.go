@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/smithy-go/middleware"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// SNSAttributeSetter sets SNS specific attributes depending on the SNS operation being performed.
+func SNSAttributeSetter(ctx context.Context, in middleware.InitializeInput) []attribute.KeyValue {
+	snsAttributes := []attribute.KeyValue{semconv.MessagingSystem("AmazonSNS")}
+
+	var arn *string
+	switch v := in.Parameters.(type) {
+	case *sns.PublishInput:
+		arn = v.TopicArn
+		if arn == nil {
+			arn = v.TargetArn
+		}
+		snsAttributes = append(snsAttributes, semconv.MessagingOperationPublish)
+	case *sns.PublishBatchInput:
+		arn = v.TopicArn
+		snsAttributes = append(snsAttributes,
+			semconv.MessagingOperationPublish,
+			semconv.MessagingBatchMessageCount(len(v.PublishBatchRequestEntries)),
+		)
+	case *sns.SubscribeInput:
+		arn = v.TopicArn
+	case *sns.GetTopicAttributesInput:
+		arn = v.TopicArn
+	case *sns.DeleteTopicInput:
+		arn = v.TopicArn
+	}
+
+	if arn != nil {
+		snsAttributes = append(snsAttributes,
+			semconv.MessagingDestinationKindTopic,
+			semconv.MessagingDestinationName(*arn),
+		)
+	}
+
+	return snsAttributes
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+func TestSNSPublishInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &sns.PublishInput{
+			TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+		},
+	}
+
+	attributes := SNSAttributeSetter(context.TODO(), input)
+
+	assert.Contains(t, attributes, semconv.MessagingSystem("AmazonSNS"))
+	assert.Contains(t, attributes, semconv.MessagingOperationPublish)
+	assert.Contains(t, attributes, semconv.MessagingDestinationKindTopic)
+	assert.Contains(t, attributes, semconv.MessagingDestinationName("arn:aws:sns:us-east-1:123456789012:test-topic"))
+}
+
+func TestSNSPublishInputTargetArn(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &sns.PublishInput{
+			TargetArn: aws.String("arn:aws:sns:us-east-1:123456789012:endpoint/GCM/app/id"),
+		},
+	}
+
+	attributes := SNSAttributeSetter(context.TODO(), input)
+
+	assert.Contains(t, attributes, semconv.MessagingDestinationName("arn:aws:sns:us-east-1:123456789012:endpoint/GCM/app/id"))
+}
+
+func TestSNSPublishBatchInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &sns.PublishBatchInput{
+			TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+			PublishBatchRequestEntries: []types.PublishBatchRequestEntry{
+				{Id: aws.String("1")},
+				{Id: aws.String("2")},
+			},
+		},
+	}
+
+	attributes := SNSAttributeSetter(context.TODO(), input)
+
+	assert.Contains(t, attributes, semconv.MessagingBatchMessageCount(2))
+	assert.Contains(t, attributes, semconv.MessagingDestinationName("arn:aws:sns:us-east-1:123456789012:test-topic"))
+}
+
+func TestSNSSubscribeInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &sns.SubscribeInput{
+			TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:test-topic"),
+		},
+	}
+
+	attributes := SNSAttributeSetter(context.TODO(), input)
+
+	assert.NotContains(t, attributes, semconv.MessagingOperationPublish)
+	assert.Contains(t, attributes, semconv.MessagingDestinationName("arn:aws:sns:us-east-1:123456789012:test-topic"))
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws // import "go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SQSMessageAttributeCarrier adapts the message attributes of an SQS message
+// to satisfy the TextMapCarrier interface.
+type SQSMessageAttributeCarrier map[string]types.MessageAttributeValue
+
+var _ propagation.TextMapCarrier = SQSMessageAttributeCarrier{}
+
+// Get returns the value of the String message attribute associated with the
+// passed key.
+func (c SQSMessageAttributeCarrier) Get(key string) string {
+	v, ok := c[key]
+	if !ok || v.StringValue == nil {
+		return ""
+	}
+	return *v.StringValue
+}
+
+// Set stores the key-value pair as a String message attribute.
+func (c SQSMessageAttributeCarrier) Set(key, value string) {
+	c[key] = sqsStringValue(value)
+}
+
+// Keys lists the keys of the message attributes.
+func (c SQSMessageAttributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func sqsStringValue(value string) types.MessageAttributeValue {
+	return types.MessageAttributeValue{
+		DataType:    aws.String("String"),
+		StringValue: aws.String(value),
+	}
+}
+
+// injectSQSMessageAttributes returns a copy of the parameters of the SQS
+// operations sending messages with the span context of ctx injected into the
+// message attributes of every message. The parameters of other operations are
+// returned unchanged, except for ReceiveMessage, which additionally requests
+// the message attributes of propagator.
+func injectSQSMessageAttributes(ctx context.Context, propagator propagation.TextMapPropagator, params interface{}) interface{} {
+	switch v := params.(type) {
+	case *sqs.SendMessageInput:
+		in := *v
+		in.MessageAttributes = injectMessageAttributes(ctx, propagator, v.MessageAttributes, sqsStringValue)
+		return &in
+	case *sqs.SendMessageBatchInput:
+		in := *v
+		in.Entries = make([]types.SendMessageBatchRequestEntry, len(v.Entries))
+		for i, entry := range v.Entries {
+			entry.MessageAttributes = injectMessageAttributes(ctx, propagator, entry.MessageAttributes, sqsStringValue)
+			in.Entries[i] = entry
+		}
+		return &in
+	case *sqs.ReceiveMessageInput:
+		names := missingAttributeNames(v.MessageAttributeNames, propagator.Fields())
+		if len(names) == 0 {
+			return v
+		}
+		in := *v
+		in.MessageAttributeNames = append(append([]string(nil), v.MessageAttributeNames...), names...)
+		return &in
+	}
+	return params
+}
+
+// missingAttributeNames returns the propagator fields that are not requested
+// by names, either directly or with the "All" or ".*" wildcards.
+func missingAttributeNames(names, propagatorFields []string) []string {
+	requested := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name == "All" || name == ".*" {
+			return nil
+		}
+		requested[name] = true
+	}
+	var missing []string
+	for _, f := range propagatorFields {
+		if !requested[f] {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// StartSQSProcessSpan starts a "process" span for msg, received from the
+// queue at queueURL, as a child of ctx and returns it along with a copy of
+// ctx holding it. The span is linked to the span context propagated in the
+// message attributes of msg, if any. The caller must end it once done
+// processing msg.
+//
+// ReceiveMessage only returns the requested message attributes. The ones of
+// the propagator are requested by the middlewares added by AppendMiddlewares
+// using the same propagator.
+func StartSQSProcessSpan(ctx context.Context, queueURL string, msg types.Message, opts ...Option) (context.Context, trace.Span) {
+	cfg := newConfig(opts...)
+
+	queue := queueURL[strings.LastIndex(queueURL, "/")+1:]
+	attrs := []attribute.KeyValue{
+		semconv.MessagingSystem("AmazonSQS"),
+		semconv.MessagingOperationProcess,
+		semconv.MessagingSourceKindQueue,
+		semconv.MessagingSourceName(queue),
+		semconv.NetPeerName(queueURL),
+	}
+	if msg.MessageId != nil {
+		attrs = append(attrs, semconv.MessagingMessageID(*msg.MessageId))
+	}
+	startOpts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attrs...),
+	}
+
+	remote := trace.SpanContextFromContext(
+		cfg.TextMapPropagator.Extract(context.Background(), SQSMessageAttributeCarrier(msg.MessageAttributes)),
+	)
+	if remote.IsValid() {
+		startOpts = append(startOpts, trace.WithLinks(trace.Link{SpanContext: remote}))
+	}
+
+	return cfg.tracer().Start(ctx, queue+" process", startOpts...)
+}
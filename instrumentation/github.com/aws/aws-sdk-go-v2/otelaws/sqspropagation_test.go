@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelaws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var sqsTestContext = trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+	TraceID:    trace.TraceID{0x01},
+	SpanID:     trace.SpanID{0x01},
+	TraceFlags: trace.FlagsSampled,
+}))
+
+func stringAttributes(n int) map[string]types.MessageAttributeValue {
+	attrs := make(map[string]types.MessageAttributeValue, n)
+	for i := 0; i < n; i++ {
+		attrs[fmt.Sprint("attr", i)] = types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String("value"),
+		}
+	}
+	return attrs
+}
+
+func TestSQSMessageAttributeCarrier(t *testing.T) {
+	carrier := SQSMessageAttributeCarrier{}
+	carrier.Set("key", "value")
+
+	assert.Equal(t, "value", carrier.Get("key"))
+	assert.Equal(t, "", carrier.Get("missing"))
+	assert.Equal(t, []string{"key"}, carrier.Keys())
+	assert.Equal(t, "String", *carrier["key"].DataType)
+}
+
+func TestInjectSQSSendMessageInput(t *testing.T) {
+	in := &sqs.SendMessageInput{
+		QueueUrl:          aws.String("test-queue-url"),
+		MessageAttributes: stringAttributes(1),
+	}
+
+	got, ok := injectSQSMessageAttributes(sqsTestContext, propagation.TraceContext{}, in).(*sqs.SendMessageInput)
+	require.True(t, ok)
+
+	assert.Len(t, in.MessageAttributes, 1, "input of the caller modified")
+	assert.Len(t, got.MessageAttributes, 2)
+	assert.Equal(t,
+		"00-01000000000000000000000000000000-0100000000000000-01",
+		SQSMessageAttributeCarrier(got.MessageAttributes).Get("traceparent"),
+	)
+}
+
+func TestInjectSQSSendMessageBatchInput(t *testing.T) {
+	in := &sqs.SendMessageBatchInput{
+		QueueUrl: aws.String("test-queue-url"),
+		Entries: []types.SendMessageBatchRequestEntry{
+			{Id: aws.String("1")},
+			{Id: aws.String("2"), MessageAttributes: stringAttributes(3)},
+		},
+	}
+
+	got, ok := injectSQSMessageAttributes(sqsTestContext, propagation.TraceContext{}, in).(*sqs.SendMessageBatchInput)
+	require.True(t, ok)
+
+	assert.Nil(t, in.Entries[0].MessageAttributes, "input of the caller modified")
+	require.Len(t, got.Entries, 2)
+	for _, entry := range got.Entries {
+		assert.NotEmpty(t, SQSMessageAttributeCarrier(entry.MessageAttributes).Get("traceparent"))
+	}
+	assert.Len(t, got.Entries[1].MessageAttributes, 4)
+}
+
+func TestInjectSQSMessageAttributesLimit(t *testing.T) {
+	prop := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, mockPropagator{
+		injectKey:   "mock-key",
+		injectValue: "mock-value",
+	})
+
+	for n, want := range map[int][]string{
+		8:  {"traceparent", "mock-key"},
+		9:  {"traceparent"},
+		10: nil,
+	} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			in := &sqs.SendMessageInput{MessageAttributes: stringAttributes(n)}
+			got := injectSQSMessageAttributes(sqsTestContext, prop, in).(*sqs.SendMessageInput)
+
+			assert.LessOrEqual(t, len(got.MessageAttributes), messageAttributesLimit)
+			assert.Len(t, got.MessageAttributes, n+len(want))
+			for _, k := range want {
+				assert.Contains(t, got.MessageAttributes, k)
+			}
+		})
+	}
+}
+
+func TestInjectSQSReceiveMessageInput(t *testing.T) {
+	prop := propagation.TraceContext{}
+
+	in := &sqs.ReceiveMessageInput{
+		QueueUrl:              aws.String("test-queue-url"),
+		MessageAttributeNames: []string{"attr", "traceparent"},
+	}
+	got := injectSQSMessageAttributes(sqsTestContext, prop, in).(*sqs.ReceiveMessageInput)
+	assert.Equal(t, []string{"attr", "traceparent"}, in.MessageAttributeNames, "input of the caller modified")
+	assert.Equal(t, []string{"attr", "traceparent", "tracestate"}, got.MessageAttributeNames)
+
+	in = &sqs.ReceiveMessageInput{MessageAttributeNames: []string{"All"}}
+	assert.Same(t, in, injectSQSMessageAttributes(sqsTestContext, prop, in))
+}
+
+func TestInjectSQSOtherInput(t *testing.T) {
+	in := &sqs.DeleteMessageInput{QueueUrl: aws.String("test-queue-url")}
+	assert.Same(t, in, injectSQSMessageAttributes(sqsTestContext, propagation.TraceContext{}, in))
+}
@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const throttlingResponse = `<?xml version="1.0"?>
+<ErrorResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+  <Error>
+    <Type>Sender</Type>
+    <Code>Throttling</Code>
+    <Message>Rate exceeded</Message>
+  </Error>
+  <RequestId>throttled</RequestId>
+</ErrorResponse>`
+
+const changeResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<ChangeResourceRecordSetsResponse>
+  <ChangeInfo>
+    <Comment>mockComment</Comment>
+    <Id>mockID</Id>
+  </ChangeInfo>
+</ChangeResourceRecordSetsResponse>`
+
+func TestRetryAttempts(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(throttlingResponse))
+			return
+		}
+		_, _ = w.Write([]byte(changeResponse))
+	}))
+	defer srv.Close()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+
+	svc := route53.NewFromConfig(aws.Config{
+		Region: "us-east-1",
+		EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(
+			func(service, region string, _ ...interface{}) (aws.Endpoint, error) {
+				return aws.Endpoint{
+					URL:         srv.URL,
+					SigningName: "route53",
+				}, nil
+			},
+		),
+		Retryer: func() aws.Retryer {
+			return retry.NewStandard(func(o *retry.StandardOptions) {
+				o.MaxAttempts = 3
+				o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) {
+					return 0, nil
+				})
+			})
+		},
+	})
+	_, err := svc.ChangeResourceRecordSets(context.Background(), &route53.ChangeResourceRecordSetsInput{
+		ChangeBatch: &types.ChangeBatch{
+			Changes: []types.Change{},
+			Comment: aws.String("mock"),
+		},
+		HostedZoneId: aws.String("zone"),
+	}, func(options *route53.Options) {
+		otelaws.AppendMiddlewares(&options.APIOptions,
+			otelaws.WithTracerProvider(tp),
+			otelaws.WithMeterProvider(mp),
+		)
+	})
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	events := spans[0].Events()
+	require.Len(t, events, 3)
+	for i, e := range events {
+		assert.Equal(t, "attempt", e.Name)
+		assert.Contains(t, e.Attributes, otelaws.AttemptAttr(i+1))
+		if i < 2 {
+			assert.Contains(t, e.Attributes, otelaws.ErrorCodeAttr("Throttling"))
+			assert.Contains(t, e.Attributes, otelaws.ThrottledKey.Bool(true))
+		} else {
+			assert.Equal(t, []attribute.KeyValue{otelaws.AttemptAttr(3)}, e.Attributes)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	opAttrs := []attribute.KeyValue{
+		otelaws.SystemAttr(),
+		otelaws.ServiceAttr("Route 53"),
+		otelaws.RegionAttr("us-east-1"),
+		otelaws.OperationAttr("ChangeResourceRecordSets"),
+	}
+	opSet := attribute.NewSet(opAttrs...)
+	throttledAttrs := attribute.NewSet(append(opAttrs, otelaws.ErrorCodeAttr("Throttling"))...)
+
+	duration, ok := metrics[otelaws.OperationDuration].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 1)
+	assert.Equal(t, opSet, duration.DataPoints[0].Attributes)
+	assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+
+	attempts, ok := metrics[otelaws.OperationAttempts].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	got := map[attribute.Distinct]int64{}
+	for _, dp := range attempts.DataPoints {
+		got[dp.Attributes.Equivalent()] = dp.Value
+	}
+	assert.Equal(t, map[attribute.Distinct]int64{
+		opSet.Equivalent():          1,
+		throttledAttrs.Equivalent(): 2,
+	}, got)
+
+	throttles, ok := metrics[otelaws.OperationThrottles].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, throttles.DataPoints, 1)
+	assert.Equal(t, throttledAttrs, throttles.DataPoints[0].Attributes)
+	assert.Equal(t, int64(2), throttles.DataPoints[0].Value)
+}
+
+func TestCanceledOperationMetrics(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// cancelDuringAttempt cancels the operation while its attempt is
+		// served instead of before it starts.
+		cancelDuringAttempt bool
+		wantAttempts        int64
+	}{
+		{name: "already canceled", wantAttempts: 0},
+		{name: "canceled during attempt", cancelDuringAttempt: true, wantAttempts: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if !tc.cancelDuringAttempt {
+				cancel()
+			}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// The client going away is only noticed once the request
+				// body is read.
+				_, _ = io.Copy(io.Discard, r.Body)
+				cancel()
+				<-r.Context().Done()
+			}))
+			defer srv.Close()
+
+			reader := metric.NewManualReader()
+			mp := metric.NewMeterProvider(metric.WithReader(reader))
+
+			svc := route53.NewFromConfig(aws.Config{
+				Region: "us-east-1",
+				EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(
+					func(service, region string, _ ...interface{}) (aws.Endpoint, error) {
+						return aws.Endpoint{
+							URL:         srv.URL,
+							SigningName: "route53",
+						}, nil
+					},
+				),
+				Retryer: func() aws.Retryer {
+					return retry.NewStandard(func(o *retry.StandardOptions) {
+						o.MaxAttempts = 1
+					})
+				},
+			})
+			_, err := svc.ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+				ChangeBatch: &types.ChangeBatch{
+					Changes: []types.Change{},
+					Comment: aws.String("mock"),
+				},
+				HostedZoneId: aws.String("zone"),
+			}, func(options *route53.Options) {
+				otelaws.AppendMiddlewares(&options.APIOptions, otelaws.WithMeterProvider(mp))
+			})
+			require.ErrorIs(t, err, context.Canceled)
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			metrics := map[string]metricdata.Metrics{}
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				metrics[m.Name] = m
+			}
+
+			duration, ok := metrics[otelaws.OperationDuration].Data.(metricdata.Histogram[float64])
+			require.True(t, ok, "duration of the canceled operation not recorded")
+			require.Len(t, duration.DataPoints, 1)
+			assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+
+			var attempts int64
+			if sum, ok := metrics[otelaws.OperationAttempts].Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range sum.DataPoints {
+					attempts += dp.Value
+				}
+			}
+			assert.Equal(t, tc.wantAttempts, attempts)
+		})
+	}
+}
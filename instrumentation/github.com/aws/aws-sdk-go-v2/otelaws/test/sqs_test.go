@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSQSPropagation(t *testing.T) {
+	var form url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		form, err = url.ParseQuery(string(body))
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	prop := propagation.TraceContext{}
+
+	svc := sqs.NewFromConfig(aws.Config{
+		Region: "us-west-2",
+		EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(
+			func(service, region string, _ ...interface{}) (aws.Endpoint, error) {
+				return aws.Endpoint{
+					URL:         srv.URL,
+					SigningName: "sqs",
+				}, nil
+			},
+		),
+		Retryer: func() aws.Retryer {
+			return aws.NopRetryer{}
+		},
+	})
+	queueURL := srv.URL + "/123456789012/test-queue"
+	_, _ = svc.SendMessage(context.Background(), &sqs.SendMessageInput{
+		QueueUrl:    aws.String(queueURL),
+		MessageBody: aws.String("body"),
+	}, func(options *sqs.Options) {
+		otelaws.AppendMiddlewares(
+			&options.APIOptions, otelaws.WithTracerProvider(provider), otelaws.WithTextMapPropagator(prop))
+	})
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	producer := spans[0]
+
+	// Rebuild the message attributes the consumer would receive.
+	require.Equal(t, "traceparent", form.Get("MessageAttribute.1.Name"))
+	msg := types.Message{
+		MessageId: aws.String("message-id"),
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			"traceparent": {
+				DataType:    aws.String(form.Get("MessageAttribute.1.Value.DataType")),
+				StringValue: aws.String(form.Get("MessageAttribute.1.Value.StringValue")),
+			},
+		},
+	}
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "receive")
+	_, span := otelaws.StartSQSProcessSpan(ctx, queueURL, msg,
+		otelaws.WithTracerProvider(provider), otelaws.WithTextMapPropagator(prop))
+	span.End()
+	parent.End()
+
+	spans = sr.Ended()
+	require.Len(t, spans, 3)
+	process := spans[1]
+	assert.Equal(t, "test-queue process", process.Name())
+	assert.Equal(t, trace.SpanKindConsumer, process.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), process.Parent().SpanID())
+	require.Len(t, process.Links(), 1)
+	assert.Equal(t, producer.SpanContext().TraceID(), process.Links()[0].SpanContext.TraceID())
+	assert.Equal(t, producer.SpanContext().SpanID(), process.Links()[0].SpanContext.SpanID())
+	assert.Contains(t, process.Attributes(), semconv.MessagingOperationProcess)
+	assert.Contains(t, process.Attributes(), semconv.MessagingSourceName("test-queue"))
+	assert.Contains(t, process.Attributes(), semconv.MessagingMessageID("message-id"))
+}
@@ -15,11 +15,16 @@
 package otelmemcache // import "go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache"
 
 import (
+	"github.com/bradfitz/gomemcache/memcache"
+
+	"go.opentelemetry.io/otel/metric"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 type config struct {
 	tracerProvider oteltrace.TracerProvider
+	meterProvider  metric.MeterProvider
+	serverSelector memcache.ServerSelector
 }
 
 // Option is used to configure the client.
@@ -42,3 +47,29 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 		}
 	})
 }
+
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.meterProvider = provider
+		}
+	})
+}
+
+// WithServerSelector specifies the server selector the memcache client was
+// created with, e.g. by memcache.NewFromSelector. It is used to resolve the
+// server address of the item keys of an operation, which is recorded as the
+// net.peer.name and net.peer.port attributes. If none is specified, the
+// server address is not recorded.
+//
+// A client created with memcache.New does not expose its servers, so it can
+// only record the server address if it is created with
+// memcache.NewFromSelector instead and the same memcache.ServerList is passed
+// to WithServerSelector.
+func WithServerSelector(selector memcache.ServerSelector) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.serverSelector = selector
+	})
+}
@@ -14,9 +14,11 @@
 
 // Package otelmemcache instruments github.com/bradfitz/gomemcache/memcache.
 //
-// This instrumentation provided is tracing instrumentation for the memcached
-// client.
+// This instrumentation provided is tracing and metric instrumentation for the
+// memcached client.
 //
 // The instrumentation works by wrapping the memcached client by calling
-// `NewClientWithTracing` and tracing it's every operation.
+// `NewClientWithTracing` and tracing it's every operation. The duration of
+// every operation, and the cache hits and misses of get operations, are
+// recorded with the meter provider set by `WithMeterProvider`.
 package otelmemcache // import "go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache"
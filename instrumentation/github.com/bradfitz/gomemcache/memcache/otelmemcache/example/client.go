@@ -54,9 +54,7 @@ func main() {
 }
 
 func doMemcacheOperations(ctx context.Context, c *otelmemcache.Client) {
-	cc := c.WithContext(ctx)
-
-	err := cc.Add(&memcache.Item{
+	err := c.AddCtx(ctx, &memcache.Item{
 		Key:   "foo",
 		Value: []byte("bar"),
 	})
@@ -64,17 +62,17 @@ func doMemcacheOperations(ctx context.Context, c *otelmemcache.Client) {
 		log.Printf("Add failed: %s", err)
 	}
 
-	_, err = cc.Get("foo")
+	_, err = c.GetCtx(ctx, "foo")
 	if err != nil {
 		log.Printf("Get failed: %s", err)
 	}
 
-	err = cc.Delete("baz")
+	err = c.DeleteCtx(ctx, "baz")
 	if err != nil {
 		log.Printf("Delete failed: %s", err)
 	}
 
-	err = cc.DeleteAll()
+	err = c.DeleteAllCtx(ctx)
 	if err != nil {
 		log.Printf("DeleteAll failed: %s", err)
 	}
@@ -16,6 +16,9 @@ package otelmemcache // import "go.opentelemetry.io/contrib/instrumentation/gith
 
 import (
 	"context"
+	"errors"
+	"net"
+	"time"
 
 	"github.com/bradfitz/gomemcache/memcache"
 
@@ -23,18 +26,22 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 const (
 	tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache"
+	meterName  = tracerName
 )
 
 // Client is a wrapper around *memcache.Client.
 type Client struct {
 	*memcache.Client
-	tracer oteltrace.Tracer
-	ctx    context.Context
+	tracer   oteltrace.Tracer
+	inst     instruments
+	selector memcache.ServerSelector
+	ctx      context.Context
 }
 
 // NewClientWithTracing wraps the provided memcache client to allow
@@ -44,8 +51,11 @@ type Client struct {
 //
 // Every client operation starts a span with appropriate attributes,
 // executes the operation and ends the span (additionally also sets a status
-// error code and message, if an error occurs). Optionally, client context can
-// be set before an operation with the WithContext method.
+// error code and message, if an error occurs). The duration of every
+// operation, and the item keys found and not found by get operations, are
+// recorded as metrics. The context of an operation can be passed to the
+// variant of the operation with the Ctx suffix, e.g. GetCtx, or the client
+// context can be set before an operation with the WithContext method.
 func NewClientWithTracing(client *memcache.Client, opts ...Option) *Client {
 	cfg := &config{}
 	for _, o := range opts {
@@ -55,14 +65,22 @@ func NewClientWithTracing(client *memcache.Client, opts ...Option) *Client {
 	if cfg.tracerProvider == nil {
 		cfg.tracerProvider = otel.GetTracerProvider()
 	}
+	if cfg.meterProvider == nil {
+		cfg.meterProvider = otel.GetMeterProvider()
+	}
 
 	return &Client{
-		client,
-		cfg.tracerProvider.Tracer(
+		Client: client,
+		tracer: cfg.tracerProvider.Tracer(
 			tracerName,
 			oteltrace.WithInstrumentationVersion(Version()),
 		),
-		context.Background(),
+		inst: newInstruments(cfg.meterProvider.Meter(
+			meterName,
+			metric.WithInstrumentationVersion(Version()),
+		)),
+		selector: cfg.serverSelector,
+		ctx:      context.Background(),
 	}
 }
 
@@ -81,143 +99,305 @@ func (c *Client) attrsByOperationAndItemKey(operation internal.Operation, key ..
 	return attributes
 }
 
+// peerAttrs returns the attributes of the server address the item keys are
+// stored on, if a server selector is configured and all the keys are stored
+// on the same server.
+func (c *Client) peerAttrs(keys ...string) []attribute.KeyValue {
+	if c.selector == nil || len(keys) == 0 {
+		return nil
+	}
+
+	var addr net.Addr
+	for _, key := range keys {
+		a, err := c.selector.PickServer(key)
+		if err != nil {
+			return nil
+		}
+		if addr != nil && a.String() != addr.String() {
+			return nil
+		}
+		addr = a
+	}
+	return internal.MemcacheNetPeer(addr)
+}
+
+// operation is a client operation in progress.
+type operation struct {
+	span  oteltrace.Span
+	start time.Time
+	// attrs are the attributes of the metrics of the operation.
+	attrs []attribute.KeyValue
+}
+
 // Starts span with appropriate span kind and attributes.
-func (c *Client) startSpan(operationName internal.Operation, itemKey ...string) oteltrace.Span {
+func (c *Client) startSpan(ctx context.Context, operationName internal.Operation, itemKey ...string) *operation {
+	peer := c.peerAttrs(itemKey...)
 	opts := []oteltrace.SpanStartOption{
 		// for database client calls, always use CLIENT span kind
 		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
 		oteltrace.WithAttributes(
 			c.attrsByOperationAndItemKey(operationName, itemKey...)...,
 		),
+		oteltrace.WithAttributes(peer...),
 	}
 
 	_, span := c.tracer.Start(
-		c.ctx,
+		ctx,
 		string(operationName),
 		opts...,
 	)
 
-	return span
+	return &operation{
+		span:  span,
+		start: time.Now(),
+		attrs: append(c.attrsByOperationAndItemKey(operationName), peer...),
+	}
 }
 
-// Ends span and, if applicable, sets error status.
-func endSpan(s oteltrace.Span, err error) {
+// Ends span and, if applicable, sets error status. The duration of the
+// operation is recorded.
+func (c *Client) endSpan(op *operation, err error) {
 	if err != nil {
-		s.SetStatus(codes.Error, err.Error())
+		op.span.SetStatus(codes.Error, err.Error())
 	}
-	s.End()
+	op.span.End()
+
+	// The operations of the client do not observe the context, so
+	// measurements are not tied to its cancellation.
+	ctx := oteltrace.ContextWithSpan(context.Background(), op.span)
+	elapsed := float64(time.Since(op.start)) / float64(time.Millisecond)
+	c.inst.operationDuration.Record(ctx, elapsed, metric.WithAttributes(op.attrs...))
+}
+
+// endLookup ends the span of a get operation which found hits and did not
+// find misses of its item keys. memcache.ErrCacheMiss is recorded as a miss
+// and not as an error.
+func (c *Client) endLookup(op *operation, hits, misses int, err error) {
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		err = nil
+	}
+
+	ctx := oteltrace.ContextWithSpan(context.Background(), op.span)
+	o := metric.WithAttributes(op.attrs...)
+	if hits > 0 {
+		c.inst.hits.Add(ctx, int64(hits), o)
+	}
+	if misses > 0 {
+		c.inst.misses.Add(ctx, int64(misses), o)
+	}
+
+	c.endSpan(op, err)
 }
 
 // WithContext retruns a copy of the client with provided context.
+//
+// The operation variants with the Ctx suffix accept the context of the
+// operation without creating a copy of the client.
 func (c *Client) WithContext(ctx context.Context) *Client {
-	cc := c.Client
-	return &Client{
-		Client: cc,
-		tracer: c.tracer,
-		ctx:    ctx,
-	}
+	cc := *c
+	cc.ctx = ctx
+	return &cc
 }
 
 // Add invokes the add operation and traces it.
 func (c *Client) Add(item *memcache.Item) error {
-	s := c.startSpan(internal.OperationAdd, item.Key)
+	return c.AddCtx(c.ctx, item)
+}
+
+// AddCtx invokes the add operation and traces it with the context ctx.
+func (c *Client) AddCtx(ctx context.Context, item *memcache.Item) error {
+	op := c.startSpan(ctx, internal.OperationAdd, item.Key)
 	err := c.Client.Add(item)
-	endSpan(s, err)
+	c.endSpan(op, err)
 	return err
 }
 
 // CompareAndSwap invokes the compare-and-swap operation and traces it.
 func (c *Client) CompareAndSwap(item *memcache.Item) error {
-	s := c.startSpan(internal.OperationCompareAndSwap, item.Key)
+	return c.CompareAndSwapCtx(c.ctx, item)
+}
+
+// CompareAndSwapCtx invokes the compare-and-swap operation and traces it
+// with the context ctx.
+func (c *Client) CompareAndSwapCtx(ctx context.Context, item *memcache.Item) error {
+	op := c.startSpan(ctx, internal.OperationCompareAndSwap, item.Key)
 	err := c.Client.CompareAndSwap(item)
-	endSpan(s, err)
+	c.endSpan(op, err)
 	return err
 }
 
 // Decrement invokes the decrement operation and traces it.
 func (c *Client) Decrement(key string, delta uint64) (uint64, error) {
-	s := c.startSpan(internal.OperationDecrement, key)
+	return c.DecrementCtx(c.ctx, key, delta)
+}
+
+// DecrementCtx invokes the decrement operation and traces it with the
+// context ctx.
+func (c *Client) DecrementCtx(ctx context.Context, key string, delta uint64) (uint64, error) {
+	op := c.startSpan(ctx, internal.OperationDecrement, key)
 	newValue, err := c.Client.Decrement(key, delta)
-	endSpan(s, err)
+	c.endSpan(op, err)
 	return newValue, err
 }
 
 // Delete invokes the delete operation and traces it.
 func (c *Client) Delete(key string) error {
-	s := c.startSpan(internal.OperationDelete, key)
+	return c.DeleteCtx(c.ctx, key)
+}
+
+// DeleteCtx invokes the delete operation and traces it with the context ctx.
+func (c *Client) DeleteCtx(ctx context.Context, key string) error {
+	op := c.startSpan(ctx, internal.OperationDelete, key)
 	err := c.Client.Delete(key)
-	endSpan(s, err)
+	c.endSpan(op, err)
 	return err
 }
 
 // DeleteAll invokes the delete all operation and traces it.
 func (c *Client) DeleteAll() error {
-	s := c.startSpan(internal.OperationDeleteAll)
+	return c.DeleteAllCtx(c.ctx)
+}
+
+// DeleteAllCtx invokes the delete all operation and traces it with the
+// context ctx.
+func (c *Client) DeleteAllCtx(ctx context.Context) error {
+	op := c.startSpan(ctx, internal.OperationDeleteAll)
 	err := c.Client.DeleteAll()
-	endSpan(s, err)
+	c.endSpan(op, err)
 	return err
 }
 
 // FlushAll invokes the flush all operation and traces it.
 func (c *Client) FlushAll() error {
-	s := c.startSpan(internal.OperationFlushAll)
+	return c.FlushAllCtx(c.ctx)
+}
+
+// FlushAllCtx invokes the flush all operation and traces it with the
+// context ctx.
+func (c *Client) FlushAllCtx(ctx context.Context) error {
+	op := c.startSpan(ctx, internal.OperationFlushAll)
 	err := c.Client.FlushAll()
-	endSpan(s, err)
+	c.endSpan(op, err)
 	return err
 }
 
 // Get invokes the get operation and traces it.
 func (c *Client) Get(key string) (*memcache.Item, error) {
-	s := c.startSpan(internal.OperationGet, key)
+	return c.GetCtx(c.ctx, key)
+}
+
+// GetCtx invokes the get operation and traces it with the context ctx.
+// Whether the item was found is recorded with the db.memcached.hit
+// attribute; memcache.ErrCacheMiss does not set an error status.
+func (c *Client) GetCtx(ctx context.Context, key string) (*memcache.Item, error) {
+	op := c.startSpan(ctx, internal.OperationGet, key)
 	item, err := c.Client.Get(key)
-	endSpan(s, err)
+	switch {
+	case err == nil:
+		op.span.SetAttributes(internal.MemcacheDBHit(true))
+		c.endLookup(op, 1, 0, err)
+	case errors.Is(err, memcache.ErrCacheMiss):
+		op.span.SetAttributes(internal.MemcacheDBHit(false))
+		c.endLookup(op, 0, 1, err)
+	default:
+		c.endLookup(op, 0, 0, err)
+	}
 	return item, err
 }
 
 // GetMulti invokes the get operation for multiple keys and traces it.
 func (c *Client) GetMulti(keys []string) (map[string]*memcache.Item, error) {
-	s := c.startSpan(internal.OperationGet, keys...)
+	return c.GetMultiCtx(c.ctx, keys)
+}
+
+// GetMultiCtx invokes the get operation for multiple keys and traces it
+// with the context ctx. The number of keys found and not found is recorded
+// with the db.memcached.hits and db.memcached.misses attributes.
+func (c *Client) GetMultiCtx(ctx context.Context, keys []string) (map[string]*memcache.Item, error) {
+	op := c.startSpan(ctx, internal.OperationGet, keys...)
 	items, err := c.Client.GetMulti(keys)
-	endSpan(s, err)
+
+	// The items found on the servers which answered are returned even if
+	// other servers failed, but the keys of these servers are neither hits
+	// nor misses.
+	hits, misses := len(items), 0
+	attrs := []attribute.KeyValue{internal.MemcacheDBHits(hits)}
+	if err == nil {
+		unique := make(map[string]struct{}, len(keys))
+		for _, key := range keys {
+			unique[key] = struct{}{}
+		}
+		misses = len(unique) - hits
+		attrs = append(attrs, internal.MemcacheDBMisses(misses))
+	}
+	op.span.SetAttributes(attrs...)
+	c.endLookup(op, hits, misses, err)
 	return items, err
 }
 
 // Increment invokes the increment operation and traces it.
 func (c *Client) Increment(key string, delta uint64) (uint64, error) {
-	s := c.startSpan(internal.OperationIncrement, key)
+	return c.IncrementCtx(c.ctx, key, delta)
+}
+
+// IncrementCtx invokes the increment operation and traces it with the
+// context ctx.
+func (c *Client) IncrementCtx(ctx context.Context, key string, delta uint64) (uint64, error) {
+	op := c.startSpan(ctx, internal.OperationIncrement, key)
 	newValue, err := c.Client.Increment(key, delta)
-	endSpan(s, err)
+	c.endSpan(op, err)
 	return newValue, err
 }
 
 // Ping invokes the ping operation and traces it.
 func (c *Client) Ping() error {
-	s := c.startSpan(internal.OperationPing)
+	return c.PingCtx(c.ctx)
+}
+
+// PingCtx invokes the ping operation and traces it with the context ctx.
+func (c *Client) PingCtx(ctx context.Context) error {
+	op := c.startSpan(ctx, internal.OperationPing)
 	err := c.Client.Ping()
-	endSpan(s, err)
+	c.endSpan(op, err)
 	return err
 }
 
 // Replace invokes the replace operation and traces it.
 func (c *Client) Replace(item *memcache.Item) error {
-	s := c.startSpan(internal.OperationReplace, item.Key)
+	return c.ReplaceCtx(c.ctx, item)
+}
+
+// ReplaceCtx invokes the replace operation and traces it with the context
+// ctx.
+func (c *Client) ReplaceCtx(ctx context.Context, item *memcache.Item) error {
+	op := c.startSpan(ctx, internal.OperationReplace, item.Key)
 	err := c.Client.Replace(item)
-	endSpan(s, err)
+	c.endSpan(op, err)
 	return err
 }
 
 // Set invokes the set operation and traces it.
 func (c *Client) Set(item *memcache.Item) error {
-	s := c.startSpan(internal.OperationSet, item.Key)
+	return c.SetCtx(c.ctx, item)
+}
+
+// SetCtx invokes the set operation and traces it with the context ctx.
+func (c *Client) SetCtx(ctx context.Context, item *memcache.Item) error {
+	op := c.startSpan(ctx, internal.OperationSet, item.Key)
 	err := c.Client.Set(item)
-	endSpan(s, err)
+	c.endSpan(op, err)
 	return err
 }
 
 // Touch invokes the touch operation and traces it.
 func (c *Client) Touch(key string, seconds int32) error {
-	s := c.startSpan(internal.OperationTouch, key)
+	return c.TouchCtx(c.ctx, key, seconds)
+}
+
+// TouchCtx invokes the touch operation and traces it with the context ctx.
+func (c *Client) TouchCtx(ctx context.Context, key string, seconds int32) error {
+	op := c.startSpan(ctx, internal.OperationTouch, key)
 	err := c.Client.Touch(key, seconds)
-	endSpan(s, err)
+	c.endSpan(op, err)
 	return err
 }
@@ -15,10 +15,21 @@
 package otelmemcache
 
 import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
 	"testing"
 
 	"github.com/bradfitz/gomemcache/memcache"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache/internal"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 func TestNewClientWithTracing(t *testing.T) {
@@ -29,3 +40,117 @@ func TestNewClientWithTracing(t *testing.T) {
 	assert.NotNil(t, c.Client)
 	assert.NotNil(t, c.tracer)
 }
+
+func TestPeerAttrs(t *testing.T) {
+	ss := new(memcache.ServerList)
+	require.NoError(t, ss.SetServers("127.0.0.1:11211"))
+
+	c := NewClientWithTracing(memcache.NewFromSelector(ss))
+	assert.Empty(t, c.peerAttrs("foo"), "without a server selector")
+
+	c = NewClientWithTracing(memcache.NewFromSelector(ss), WithServerSelector(ss))
+	want := []attribute.KeyValue{
+		semconv.NetPeerName("127.0.0.1"),
+		semconv.NetPeerPort(11211),
+	}
+	assert.Equal(t, want, c.peerAttrs("foo"))
+	assert.Equal(t, want, c.peerAttrs("foo", "bar"))
+	assert.Empty(t, c.peerAttrs(), "without item keys")
+
+	require.NoError(t, ss.SetServers("127.0.0.1:11211", "127.0.0.1:11212"))
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	assert.Empty(t, c.peerAttrs(keys...), "with keys on different servers")
+}
+
+// keySelector is a memcache.ServerSelector picking the server of each key
+// from a map.
+type keySelector map[string]net.Addr
+
+func (s keySelector) PickServer(key string) (net.Addr, error) {
+	return s[key], nil
+}
+
+func (s keySelector) Each(f func(net.Addr) error) error {
+	for _, addr := range s {
+		if err := f(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordingSpan is a span keeping its attributes and status code.
+type recordingSpan struct {
+	oteltrace.Span
+	attrs  []attribute.KeyValue
+	status codes.Code
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attrs = append(s.attrs, kv...)
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, _ string) {
+	s.status = code
+}
+
+// recordingTracer starts its span for every operation.
+type recordingTracer struct {
+	oteltrace.Tracer
+	span *recordingSpan
+}
+
+func (t recordingTracer) Start(ctx context.Context, _ string, _ ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	return ctx, t.span
+}
+
+// serveItem serves the item with key and value to the gets commands of the
+// connections accepted by l.
+func serveItem(l net.Listener, key, value string) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+			for {
+				if _, err := rw.ReadString('\n'); err != nil {
+					return
+				}
+				_, _ = rw.WriteString("VALUE " + key + " 0 " + strconv.Itoa(len(value)) + " 1\r\n" + value + "\r\nEND\r\n")
+				if err := rw.Flush(); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+func TestGetMultiPartialFailure(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	go serveItem(l, "foo", "bar")
+
+	// Nothing listens on the address of the closed listener.
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, closed.Close())
+
+	selector := keySelector{"foo": l.Addr(), "baz": closed.Addr()}
+	c := NewClientWithTracing(memcache.NewFromSelector(selector))
+	span := &recordingSpan{Span: oteltrace.SpanFromContext(context.Background())}
+	c.tracer = recordingTracer{Tracer: c.tracer, span: span}
+
+	items, err := c.GetMulti([]string{"foo", "baz"})
+	require.Error(t, err)
+	assert.Len(t, items, 1)
+
+	assert.Equal(t, codes.Error, span.status)
+	assert.Contains(t, span.attrs, internal.MemcacheDBHits(1))
+	for _, attr := range span.attrs {
+		assert.NotEqual(t, internal.MemcacheDBMisses(0).Key, attr.Key, "the keys of the failed server are not misses")
+	}
+}
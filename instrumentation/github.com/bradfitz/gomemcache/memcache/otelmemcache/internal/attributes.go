@@ -15,6 +15,9 @@
 package internal // import "go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache/internal"
 
 import (
+	"net"
+	"strconv"
+
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 )
@@ -36,7 +39,10 @@ const (
 	OperationSet            Operation = "set"
 	OperationTouch          Operation = "touch"
 
-	MemcacheDBItemKeyName attribute.Key = "db.memcached.item"
+	MemcacheDBItemKeyName   attribute.Key = "db.memcached.item"
+	MemcacheDBHitKeyName    attribute.Key = "db.memcached.hit"
+	MemcacheDBHitsKeyName   attribute.Key = "db.memcached.hits"
+	MemcacheDBMissesKeyName attribute.Key = "db.memcached.misses"
 )
 
 func MemcacheDBSystem() attribute.KeyValue {
@@ -54,3 +60,28 @@ func MemcacheDBItemKeys(itemKeys ...string) attribute.KeyValue {
 
 	return MemcacheDBItemKeyName.String(itemKeys[0])
 }
+
+func MemcacheDBHit(hit bool) attribute.KeyValue {
+	return MemcacheDBHitKeyName.Bool(hit)
+}
+
+func MemcacheDBHits(hits int) attribute.KeyValue {
+	return MemcacheDBHitsKeyName.Int(hits)
+}
+
+func MemcacheDBMisses(misses int) attribute.KeyValue {
+	return MemcacheDBMissesKeyName.Int(misses)
+}
+
+func MemcacheNetPeer(addr net.Addr) []attribute.KeyValue {
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		// Unix domain socket paths have no port.
+		return []attribute.KeyValue{semconv.NetPeerName(addr.String())}
+	}
+	attrs := []attribute.KeyValue{semconv.NetPeerName(host)}
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, semconv.NetPeerPort(p))
+	}
+	return attrs
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmemcache // import "go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache"
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Names of the instruments recorded by the client.
+const (
+	OperationDuration = "db.client.operation.duration" // Duration of client operations
+	Hits              = "db.memcached.hits"            // Number of item keys found by get operations
+	Misses            = "db.memcached.misses"          // Number of item keys not found by get operations
+)
+
+type instruments struct {
+	operationDuration metric.Float64Histogram
+	hits              metric.Int64Counter
+	misses            metric.Int64Counter
+}
+
+func newInstruments(meter metric.Meter) instruments {
+	var (
+		i   instruments
+		err error
+	)
+
+	i.operationDuration, err = meter.Float64Histogram(
+		OperationDuration,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of memcached client operations."),
+	)
+	handleErr(err)
+
+	i.hits, err = meter.Int64Counter(
+		Hits,
+		metric.WithUnit("{key}"),
+		metric.WithDescription("The number of item keys found in the cache by get operations."),
+	)
+	handleErr(err)
+
+	i.misses, err = meter.Int64Counter(
+		Misses,
+		metric.WithUnit("{key}"),
+		metric.WithDescription("The number of item keys not found in the cache by get operations."),
+	)
+	handleErr(err)
+
+	return i
+}
+
+func handleErr(err error) {
+	if err != nil {
+		otel.Handle(err)
+	}
+}
@@ -15,6 +15,7 @@
 package test
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -25,9 +26,14 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/bradfitz/gomemcache/memcache/otelmemcache/internal"
 	"go.opentelemetry.io/contrib/internal/util"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
@@ -50,41 +56,149 @@ func TestOperation(t *testing.T) {
 	assert.Len(t, spans, 1)
 	assert.Equal(t, oteltrace.SpanKindClient, spans[0].SpanKind())
 	assert.Equal(t, string(internal.OperationAdd), spans[0].Name())
-	assert.Len(t, spans[0].Attributes(), 3)
+	assert.Len(t, spans[0].Attributes(), 5)
 
 	attrs := spans[0].Attributes()
 	assert.Contains(t, attrs, internal.MemcacheDBSystem())
 	assert.Contains(t, attrs, internal.MemcacheDBOperation(internal.OperationAdd))
+	assert.Contains(t, attrs, semconv.NetPeerPort(11211))
 	assert.Contains(t, attrs, internal.MemcacheDBItemKeyName.String(mi.Key))
 }
 
-func TestOperationWithCacheMissError(t *testing.T) {
+func TestOperationWithCacheMiss(t *testing.T) {
 	key := "foo"
 	c, sr := initClientWithSpanRecorder(t)
 
 	_, err := c.Get(key)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, memcache.ErrCacheMiss)
 
 	spans := sr.Ended()
 	assert.Len(t, spans, 1)
 	assert.Equal(t, oteltrace.SpanKindClient, spans[0].SpanKind())
 	assert.Equal(t, string(internal.OperationGet), spans[0].Name())
-	assert.Len(t, spans[0].Attributes(), 3)
+	assert.Len(t, spans[0].Attributes(), 6)
 
 	attrs := spans[0].Attributes()
 	assert.Contains(t, attrs, internal.MemcacheDBSystem())
 	assert.Contains(t, attrs, internal.MemcacheDBOperation(internal.OperationGet))
 	assert.Contains(t, attrs, internal.MemcacheDBItemKeyName.String(key))
+	assert.Contains(t, attrs, internal.MemcacheDBHit(false))
 
+	// A cache miss is not an error.
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+}
+
+func TestOperationWithError(t *testing.T) {
+	c, sr := initClientWithSpanRecorder(t)
+
+	_, err := c.Get("invalid key")
+	assert.ErrorIs(t, err, memcache.ErrMalformedKey)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
 	assert.Equal(t, codes.Error, spans[0].Status().Code)
 	assert.Equal(t, err.Error(), spans[0].Status().Description)
+	assert.NotContains(t, spans[0].Attributes(), internal.MemcacheDBHit(false))
+}
+
+func TestOperationWithContext(t *testing.T) {
+	c, sr := initClientWithSpanRecorder(t)
+
+	parent := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{0x01},
+		SpanID:     oteltrace.SpanID{0x01},
+		TraceFlags: oteltrace.FlagsSampled,
+	})
+	ctx := oteltrace.ContextWithSpanContext(context.Background(), parent)
+
+	mi := &memcache.Item{
+		Key:   "foo",
+		Value: []byte("bar"),
+	}
+	require.NoError(t, c.SetCtx(ctx, mi))
+	item, err := c.GetCtx(ctx, mi.Key)
+	require.NoError(t, err)
+	assert.Equal(t, mi.Value, item.Value)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	for _, span := range spans {
+		assert.Equal(t, parent.TraceID(), span.Parent().TraceID())
+		assert.Equal(t, parent.SpanID(), span.Parent().SpanID())
+		assert.Contains(t, span.Attributes(), semconv.NetPeerPort(11211))
+	}
+	assert.Contains(t, spans[1].Attributes(), internal.MemcacheDBHit(true))
+}
+
+func TestMetrics(t *testing.T) {
+	c, _ := initClientWithSpanRecorder(t)
+	reader := metric.NewManualReader()
+	c = otelmemcache.NewClientWithTracing(
+		c.Client,
+		otelmemcache.WithMeterProvider(metric.NewMeterProvider(metric.WithReader(reader))),
+	)
+
+	require.NoError(t, c.Set(&memcache.Item{Key: "foo", Value: []byte("bar")}))
+	items, err := c.GetMultiCtx(context.Background(), []string{"foo", "baz", "qux"})
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+	_, err = c.GetCtx(context.Background(), "baz")
+	assert.ErrorIs(t, err, memcache.ErrCacheMiss)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	got := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		got[m.Name] = m
+	}
+
+	getAttrs := attribute.NewSet(
+		internal.MemcacheDBSystem(),
+		internal.MemcacheDBOperation(internal.OperationGet),
+	)
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        otelmemcache.Hits,
+		Description: "The number of item keys found in the cache by get operations.",
+		Unit:        "{key}",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints:  []metricdata.DataPoint[int64]{{Attributes: getAttrs, Value: 1}},
+		},
+	}, got[otelmemcache.Hits], metricdatatest.IgnoreTimestamp())
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        otelmemcache.Misses,
+		Description: "The number of item keys not found in the cache by get operations.",
+		Unit:        "{key}",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints:  []metricdata.DataPoint[int64]{{Attributes: getAttrs, Value: 3}},
+		},
+	}, got[otelmemcache.Misses], metricdatatest.IgnoreTimestamp())
+
+	duration, ok := got[otelmemcache.OperationDuration].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	counts := map[attribute.Distinct]uint64{}
+	for _, dp := range duration.DataPoints {
+		counts[dp.Attributes.Equivalent()] = dp.Count
+	}
+	setAttrs := attribute.NewSet(
+		internal.MemcacheDBSystem(),
+		internal.MemcacheDBOperation(internal.OperationSet),
+	)
+	assert.Equal(t, uint64(1), counts[setAttrs.Equivalent()])
+	assert.Equal(t, uint64(2), counts[getAttrs.Equivalent()])
 }
 
 // tests require running memcached instance.
 func initClientWithSpanRecorder(t *testing.T) (*otelmemcache.Client, *tracetest.SpanRecorder) {
 	host, port := "localhost", "11211"
 
-	mc := memcache.New(host + ":" + port)
+	ss := new(memcache.ServerList)
+	require.NoError(t, ss.SetServers(host+":"+port))
+	mc := memcache.NewFromSelector(ss)
 	require.NoError(t, clearDB(mc))
 
 	sr := tracetest.NewSpanRecorder()
@@ -93,6 +207,7 @@ func initClientWithSpanRecorder(t *testing.T) (*otelmemcache.Client, *tracetest.
 		otelmemcache.WithTracerProvider(
 			trace.NewTracerProvider(trace.WithSpanProcessor(sr)),
 		),
+		otelmemcache.WithServerSelector(ss),
 	)
 
 	return c, sr
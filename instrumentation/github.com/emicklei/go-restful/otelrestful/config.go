@@ -17,6 +17,7 @@ package otelrestful // import "go.opentelemetry.io/contrib/instrumentation/githu
 import (
 	"net/http"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
@@ -24,6 +25,7 @@ import (
 // config is used to configure the go-restful middleware.
 type config struct {
 	TracerProvider   oteltrace.TracerProvider
+	MeterProvider    metric.MeterProvider
 	Propagators      propagation.TextMapPropagator
 	PublicEndpoint   bool
 	PublicEndpointFn func(*http.Request) bool
@@ -70,6 +72,16 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithPublicEndpointFn runs with every request, and allows conditionnally
 // configuring the Handler to link the span with an incoming span context. If
 // this option is not provided or returns false, then the association is a
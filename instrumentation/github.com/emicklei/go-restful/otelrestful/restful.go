@@ -15,22 +15,31 @@
 package otelrestful // import "go.opentelemetry.io/contrib/instrumentation/github.com/emicklei/go-restful/otelrestful"
 
 import (
+	"time"
+
 	"github.com/emicklei/go-restful/v3"
 
 	"go.opentelemetry.io/contrib/instrumentation/github.com/emicklei/go-restful/otelrestful/internal/semconvutil"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
-const tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/emicklei/go-restful/otelrestful"
+const (
+	tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/emicklei/go-restful/otelrestful"
+	meterName  = tracerName
+)
 
-// OTelFilter returns a restful.FilterFunction which will trace an incoming request.
+// OTelFilter returns a restful.FilterFunction which will trace an incoming
+// request and record its metrics.
 //
 // The service parameter should describe the name of the (virtual) server handling
-// the request.  Options can be applied to configure the tracer and propagators
-// used for this filter.
+// the request.  Options can be applied to configure the tracer, meter and
+// propagators used for this filter. The metrics have the http.route attribute
+// of the path of the selected route, e.g. /user/{id}, instead of the request
+// path.
 func OTelFilter(service string, opts ...Option) restful.FilterFunction {
 	cfg := config{}
 	for _, opt := range opts {
@@ -43,10 +52,18 @@ func OTelFilter(service string, opts ...Option) restful.FilterFunction {
 		tracerName,
 		oteltrace.WithInstrumentationVersion(Version()),
 	)
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = otel.GetMeterProvider()
+	}
+	inst := newInstruments(cfg.MeterProvider.Meter(
+		meterName,
+		metric.WithInstrumentationVersion(Version()),
+	))
 	if cfg.Propagators == nil {
 		cfg.Propagators = otel.GetTextMapPropagator()
 	}
 	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		requestStartTime := time.Now()
 		r := req.Request
 		ctx := cfg.Propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
 		route := req.SelectedRoutePath()
@@ -56,9 +73,11 @@ func OTelFilter(service string, opts ...Option) restful.FilterFunction {
 			oteltrace.WithAttributes(semconvutil.HTTPServerRequest(service, r)...),
 			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 		}
+		metricAttrs := semconvutil.HTTPServerRequestMetrics(service, r)
 		if route != "" {
 			rAttr := semconv.HTTPRoute(route)
 			opts = append(opts, oteltrace.WithAttributes(rAttr))
+			metricAttrs = append(metricAttrs, rAttr)
 		}
 
 		if cfg.PublicEndpoint || (cfg.PublicEndpointFn != nil && cfg.PublicEndpointFn(r.WithContext(ctx))) {
@@ -75,12 +94,27 @@ func OTelFilter(service string, opts ...Option) restful.FilterFunction {
 		// pass the span through the request context
 		req.Request = req.Request.WithContext(ctx)
 
+		activeAttrs := metric.WithAttributes(metricAttrs...)
+		inst.activeRequests.Add(ctx, 1, activeAttrs)
+		defer inst.activeRequests.Add(withoutCancel(ctx), -1, activeAttrs)
+
 		chain.ProcessFilter(req, resp)
 
 		status := resp.StatusCode()
 		span.SetStatus(semconvutil.HTTPServerStatus(status))
 		if status > 0 {
 			span.SetAttributes(semconv.HTTPStatusCode(status))
+			metricAttrs = append(metricAttrs, semconv.HTTPStatusCode(status))
+		}
+
+		ctx, o := withoutCancel(ctx), metric.WithAttributes(metricAttrs...)
+		if r.ContentLength > 0 {
+			inst.requestSize.Add(ctx, r.ContentLength, o)
+		}
+		if size := resp.ContentLength(); size > 0 {
+			inst.responseSize.Add(ctx, int64(size), o)
 		}
+		elapsedTime := float64(time.Since(requestStartTime)) / float64(time.Millisecond)
+		inst.duration.Record(ctx, elapsedTime, o)
 	}
 }
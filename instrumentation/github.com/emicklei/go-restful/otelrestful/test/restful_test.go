@@ -15,9 +15,11 @@
 package test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/emicklei/go-restful/v3"
@@ -28,6 +30,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	oteltrace "go.opentelemetry.io/otel/trace"
@@ -211,3 +214,63 @@ func assertSpan(t *testing.T, span sdktrace.ReadOnlySpan, name string, attrs ...
 		assert.Contains(t, gotA, a)
 	}
 }
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	// The client goes away while the request is served, which cancels the
+	// context of the request.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handlerFunc := func(req *restful.Request, resp *restful.Response) {
+		cancel()
+		_, _ = resp.Write([]byte("ok"))
+	}
+	ws := &restful.WebService{}
+	ws.Route(ws.POST("/user/{id}").To(handlerFunc))
+
+	container := restful.NewContainer()
+	container.Filter(otelrestful.OTelFilter("my-service", otelrestful.WithMeterProvider(provider)))
+	container.Add(ws)
+
+	r := httptest.NewRequest("POST", "/user/123", strings.NewReader("hello")).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	container.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/github.com/emicklei/go-restful/otelrestful", serverMetrics{
+		route:        "/user/{id}",
+		status:       http.StatusOK,
+		requestSize:  5,
+		responseSize: 2,
+	})
+}
+
+func TestMetricsErrorStatus(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	handlerFunc := func(req *restful.Request, resp *restful.Response) {
+		_ = resp.WriteErrorString(http.StatusInternalServerError, "error")
+	}
+	ws := &restful.WebService{}
+	ws.Route(ws.POST("/user/{id}").To(handlerFunc))
+
+	container := restful.NewContainer()
+	container.Filter(otelrestful.OTelFilter("my-service", otelrestful.WithMeterProvider(provider)))
+	container.Add(ws)
+
+	r := httptest.NewRequest("POST", "/user/123", strings.NewReader("hello"))
+	w := httptest.NewRecorder()
+
+	container.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/github.com/emicklei/go-restful/otelrestful", serverMetrics{
+		route:        "/user/{id}",
+		status:       http.StatusInternalServerError,
+		requestSize:  5,
+		responseSize: int64(w.Body.Len()),
+	})
+}
@@ -0,0 +1,83 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/servermetric/servermetric_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// serverMetrics are the expected metrics of a single request.
+type serverMetrics struct {
+	// route is the http.route attribute, or empty if the metrics must not
+	// have it.
+	route        string
+	status       int
+	requestSize  int64
+	responseSize int64
+}
+
+// assertServerMetrics asserts that reader collected the metrics of a single
+// request described by want under the instrumentation scope.
+func assertServerMetrics(t *testing.T, reader sdkmetric.Reader, scope string, want serverMetrics) {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sm := rm.ScopeMetrics[0]
+	assert.Equal(t, scope, sm.Scope.Name)
+
+	got := map[string]metricdata.Aggregation{}
+	for _, m := range sm.Metrics {
+		got[m.Name] = m.Data
+	}
+	require.Len(t, got, 4)
+
+	duration, ok := got["http.server.duration"].(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 1)
+	assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+	attrs := duration.DataPoints[0].Attributes
+	if want.route == "" {
+		assert.False(t, attrs.HasValue(semconv.HTTPRouteKey), "unexpected http.route")
+	} else {
+		route, _ := attrs.Value(semconv.HTTPRouteKey)
+		assert.Equal(t, want.route, route.AsString())
+	}
+	status, _ := attrs.Value(semconv.HTTPStatusCodeKey)
+	assert.Equal(t, int64(want.status), status.AsInt64())
+	assert.False(t, attrs.HasValue(semconv.HTTPTargetKey), "http.target is high cardinality")
+
+	assertSum := func(name string, want int64) {
+		sum, ok := got[name].(metricdata.Sum[int64])
+		require.True(t, ok, name)
+		require.Len(t, sum.DataPoints, 1, name)
+		assert.Equal(t, want, sum.DataPoints[0].Value, name)
+	}
+	assertSum("http.server.request_content_length", want.requestSize)
+	assertSum("http.server.response_content_length", want.responseSize)
+	assertSum("http.server.active_requests", 0)
+}
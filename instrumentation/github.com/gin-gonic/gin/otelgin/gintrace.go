@@ -18,6 +18,7 @@ package otelgin // import "go.opentelemetry.io/contrib/instrumentation/github.co
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -25,6 +26,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
@@ -33,11 +35,14 @@ import (
 const (
 	tracerKey  = "otel-go-contrib-tracer"
 	tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	meterName  = tracerName
 )
 
-// Middleware returns middleware that will trace incoming requests.
-// The service parameter should describe the name of the (virtual)
-// server handling the request.
+// Middleware returns middleware that will trace incoming requests and
+// record their metrics. The metrics have the http.route attribute of the
+// route template matched by the request, e.g. /user/:id, instead of the
+// request path. The service parameter should describe the name of the
+// (virtual) server handling the request.
 func Middleware(service string, opts ...Option) gin.HandlerFunc {
 	cfg := config{}
 	for _, opt := range opts {
@@ -53,7 +58,15 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 	if cfg.Propagators == nil {
 		cfg.Propagators = otel.GetTextMapPropagator()
 	}
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = otel.GetMeterProvider()
+	}
+	inst := newInstruments(cfg.MeterProvider.Meter(
+		meterName,
+		metric.WithInstrumentationVersion(Version()),
+	))
 	return func(c *gin.Context) {
+		requestStartTime := time.Now()
 		for _, f := range cfg.Filters {
 			if !f(c.Request) {
 				// Serve the request to the next middleware
@@ -90,6 +103,14 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 		// pass the span through the request context
 		c.Request = c.Request.WithContext(ctx)
 
+		metricAttrs := semconvutil.HTTPServerRequestMetrics(service, c.Request)
+		if route := c.FullPath(); route != "" {
+			metricAttrs = append(metricAttrs, semconv.HTTPRoute(route))
+		}
+		activeAttrs := metric.WithAttributes(metricAttrs...)
+		inst.activeRequests.Add(ctx, 1, activeAttrs)
+		defer inst.activeRequests.Add(withoutCancel(ctx), -1, activeAttrs)
+
 		// serve the request to the next middleware
 		c.Next()
 
@@ -97,7 +118,17 @@ func Middleware(service string, opts ...Option) gin.HandlerFunc {
 		span.SetStatus(semconvutil.HTTPServerStatus(status))
 		if status > 0 {
 			span.SetAttributes(semconv.HTTPStatusCode(status))
+			metricAttrs = append(metricAttrs, semconv.HTTPStatusCode(status))
+		}
+		ctx, o := withoutCancel(ctx), metric.WithAttributes(metricAttrs...)
+		if size := c.Request.ContentLength; size > 0 {
+			inst.requestSize.Add(ctx, size, o)
+		}
+		if size := c.Writer.Size(); size > 0 {
+			inst.responseSize.Add(ctx, int64(size), o)
 		}
+		elapsedTime := float64(time.Since(requestStartTime)) / float64(time.Millisecond)
+		inst.duration.Record(ctx, elapsedTime, o)
 		if len(c.Errors) > 0 {
 			span.SetAttributes(attribute.String("gin.errors", c.Errors.String()))
 		}
@@ -17,11 +17,13 @@
 package test
 
 import (
+	"context"
 	"errors"
 	"html/template"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -31,6 +33,7 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
@@ -262,3 +265,79 @@ func TestWithFilter(t *testing.T) {
 		assert.Len(t, sr.Ended(), 1)
 	})
 }
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	// The client goes away while the request is served, which cancels the
+	// context of the request.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	router := gin.New()
+	router.Use(otelgin.Middleware("foobar", otelgin.WithMeterProvider(provider)))
+	router.POST("/user/:id", func(c *gin.Context) {
+		cancel()
+		c.String(http.StatusOK, "ok")
+	})
+
+	r := httptest.NewRequest("POST", "/user/123", strings.NewReader("hello")).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin", serverMetrics{
+		route:        "/user/:id",
+		status:       http.StatusOK,
+		requestSize:  5,
+		responseSize: 2,
+	})
+}
+
+func TestMetricsUnmatchedRoute(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	router := gin.New()
+	router.Use(otelgin.Middleware("foobar", otelgin.WithMeterProvider(provider)))
+	router.POST("/user/:id", func(c *gin.Context) {})
+	router.NoRoute(func(c *gin.Context) {
+		c.String(http.StatusNotFound, "not found")
+	})
+
+	r := httptest.NewRequest("POST", "/book/123", strings.NewReader("hello"))
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin", serverMetrics{
+		status:       http.StatusNotFound,
+		requestSize:  5,
+		responseSize: int64(w.Body.Len()),
+	})
+}
+
+func TestMetricsErrorStatus(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	router := gin.New()
+	router.Use(otelgin.Middleware("foobar", otelgin.WithMeterProvider(provider)))
+	router.POST("/user/:id", func(c *gin.Context) {
+		_ = c.AbortWithError(http.StatusInternalServerError, errors.New("oh no"))
+		c.String(http.StatusInternalServerError, "error")
+	})
+
+	r := httptest.NewRequest("POST", "/user/123", strings.NewReader("hello"))
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin", serverMetrics{
+		route:        "/user/:id",
+		status:       http.StatusInternalServerError,
+		requestSize:  5,
+		responseSize: int64(w.Body.Len()),
+	})
+}
@@ -19,7 +19,9 @@ package otelkit // import "go.opentelemetry.io/contrib/instrumentation/github.co
 import (
 	"context"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -28,6 +30,10 @@ type config struct {
 	// TracerProvider provides access to instrumentation Tracers.
 	TracerProvider trace.TracerProvider
 
+	// Propagators injects and extracts span contexts in the transport
+	// before functions.
+	Propagators propagation.TextMapPropagator
+
 	// IgnoreBusinessError if set to true will not treat a business error
 	// identified through the endpoint.Failer interface as a span error.
 	IgnoreBusinessError bool
@@ -49,7 +55,26 @@ type config struct {
 	GetAttributes func(ctx context.Context) []attribute.KeyValue
 }
 
-// Option configures an EndpointMiddleware.
+// newConfig returns a config with all Options set.
+func newConfig(options []Option) *config {
+	cfg := &config{}
+
+	for _, o := range options {
+		o.apply(cfg)
+	}
+
+	if cfg.TracerProvider == nil {
+		cfg.TracerProvider = otel.GetTracerProvider()
+	}
+	if cfg.Propagators == nil {
+		cfg.Propagators = otel.GetTextMapPropagator()
+	}
+
+	return cfg
+}
+
+// Option configures an EndpointMiddleware, a ClientEndpointMiddleware or a
+// transport before function.
 type Option interface {
 	apply(*config)
 }
@@ -70,6 +95,17 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithPropagators specifies propagators to use for injecting and extracting
+// span contexts in the transport before functions.
+// If none are specified, the global ones are used.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return optionFunc(func(o *config) {
+		if propagators != nil {
+			o.Propagators = propagators
+		}
+	})
+}
+
 // WithIgnoreBusinessError if set to true will not treat a business error
 // identified through the endpoint.Failer interface as a span error.
 func WithIgnoreBusinessError(val bool) Option {
@@ -15,8 +15,9 @@
 // Package otelkit instruments the github.com/go-kit/kit package.
 //
 // Compared to other instrumentation libraries provided by go-kit itself,
-// this package only provides instrumentation for the endpoint layer.
-// For instrumenting the transport layer,
+// this package only provides instrumentation for the endpoint layer, along
+// with before functions propagating the span context through the go-kit HTTP
+// and gRPC transports. For instrumenting the transport layer,
 // look at the instrumentation libraries provided by go.opentelemetry.io/contrib.
 // Learn more about go-kit's layers at https://gokit.io/faq/#architecture-and-design.
 //
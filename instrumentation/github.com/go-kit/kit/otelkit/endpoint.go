@@ -19,7 +19,6 @@ package otelkit // import "go.opentelemetry.io/contrib/instrumentation/github.co
 import (
 	"context"
 
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -38,17 +37,24 @@ const (
 // EndpointMiddleware returns an Endpoint middleware, tracing a Go kit endpoint.
 // This endpoint middleware should be used in combination with a Go kit Transport
 // tracing middleware, generic OpenTelemetry transport middleware or custom before
-// and after transport functions.
+// and after transport functions, such as HTTPToContext and GRPCToContext.
 func EndpointMiddleware(options ...Option) endpoint.Middleware {
-	cfg := &config{}
+	return endpointMiddleware(trace.SpanKindServer, options)
+}
 
-	for _, o := range options {
-		o.apply(cfg)
-	}
+// ClientEndpointMiddleware returns an Endpoint middleware, tracing a Go kit
+// client endpoint, such as the endpoint of a Go kit HTTP or gRPC transport
+// client. The span context of the client span is propagated to the server
+// when the transport client is created with the ContextToHTTP or
+// ContextToGRPC before functions.
+func ClientEndpointMiddleware(options ...Option) endpoint.Middleware {
+	return endpointMiddleware(trace.SpanKindClient, options)
+}
 
-	if cfg.TracerProvider == nil {
-		cfg.TracerProvider = otel.GetTracerProvider()
-	}
+// endpointMiddleware returns an Endpoint middleware starting spans of the
+// given kind.
+func endpointMiddleware(kind trace.SpanKind, options []Option) endpoint.Middleware {
+	cfg := newConfig(options)
 
 	tracer := cfg.TracerProvider.Tracer(
 		tracerName,
@@ -71,7 +77,7 @@ func EndpointMiddleware(options ...Option) endpoint.Middleware {
 
 			opts := []trace.SpanStartOption{
 				trace.WithAttributes(cfg.Attributes...),
-				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithSpanKind(kind),
 			}
 
 			if cfg.GetAttributes != nil {
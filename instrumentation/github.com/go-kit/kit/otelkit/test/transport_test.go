@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit" //nolint:staticcheck // This is deprecated and will be removed in the next release.
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestClientEndpointMiddleware(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	mw := otelkit.ClientEndpointMiddleware(
+		otelkit.WithTracerProvider(provider),
+		otelkit.WithOperation("operation"),
+	)
+
+	_, _ = mw(passEndpoint)(context.Background(), nil)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "operation", spans[0].Name())
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+}
+
+func TestHTTPPropagation(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	opts := []otelkit.Option{
+		otelkit.WithTracerProvider(provider),
+		otelkit.WithPropagators(propagation.TraceContext{}),
+	}
+
+	server := httptest.NewServer(kithttp.NewServer(
+		otelkit.EndpointMiddleware(opts...)(passEndpoint),
+		func(context.Context, *http.Request) (interface{}, error) { return nil, nil },
+		func(context.Context, http.ResponseWriter, interface{}) error { return nil },
+		kithttp.ServerBefore(otelkit.HTTPToContext(opts...)),
+	))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	client := kithttp.NewExplicitClient(
+		func(context.Context, interface{}) (*http.Request, error) { return req, nil },
+		func(context.Context, *http.Response) (interface{}, error) { return nil, nil },
+		kithttp.ClientBefore(otelkit.ContextToHTTP(opts...)),
+	)
+
+	_, err = otelkit.ClientEndpointMiddleware(opts...)(client.Endpoint())(context.Background(), nil)
+	require.NoError(t, err)
+
+	assertPropagated(t, sr.Ended())
+}
+
+func TestGRPCPropagation(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	opts := []otelkit.Option{
+		otelkit.WithTracerProvider(provider),
+		otelkit.WithPropagators(propagation.TraceContext{}),
+	}
+
+	// Pass the metadata injected by the client before function to the
+	// server before function, as the gRPC transport would.
+	var md metadata.MD
+	client := func(ctx context.Context, request interface{}) (interface{}, error) {
+		md = metadata.MD{}
+		otelkit.ContextToGRPC(opts...)(ctx, &md)
+		return nil, nil
+	}
+	_, err := otelkit.ClientEndpointMiddleware(opts...)(client)(context.Background(), nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, md.Get("traceparent"))
+
+	ctx := otelkit.GRPCToContext(opts...)(context.Background(), md)
+	_, err = otelkit.EndpointMiddleware(opts...)(passEndpoint)(ctx, nil)
+	require.NoError(t, err)
+
+	assertPropagated(t, sr.Ended())
+}
+
+// assertPropagated asserts that the server span is a child of the client
+// span.
+func assertPropagated(t *testing.T, spans []sdktrace.ReadOnlySpan) {
+	t.Helper()
+
+	var client, server sdktrace.ReadOnlySpan
+	for _, span := range spans {
+		switch span.SpanKind() {
+		case trace.SpanKindClient:
+			client = span
+		case trace.SpanKindServer:
+			server = span
+		}
+	}
+	require.NotNil(t, client, "client span")
+	require.NotNil(t, server, "server span")
+
+	assert.Equal(t, client.SpanContext().TraceID(), server.SpanContext().TraceID())
+	assert.Equal(t, client.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.True(t, server.Parent().IsRemote())
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelkit // import "go.opentelemetry.io/contrib/instrumentation/github.com/go-kit/kit/otelkit"
+
+import (
+	"context"
+	"net/http"
+
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// ContextToHTTP returns a Go kit HTTP RequestFunc injecting the span context
+// of the context into the headers of the outgoing request. Use it as a
+// before function of a Go kit HTTP client, e.g. with kithttp.ClientBefore.
+func ContextToHTTP(options ...Option) kithttp.RequestFunc {
+	cfg := newConfig(options)
+
+	return func(ctx context.Context, req *http.Request) context.Context {
+		cfg.Propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+		return ctx
+	}
+}
+
+// HTTPToContext returns a Go kit HTTP RequestFunc extracting the span
+// context from the headers of the incoming request into the context. Use it
+// as a before function of a Go kit HTTP server, e.g. with
+// kithttp.ServerBefore, so the spans of EndpointMiddleware are children of
+// the remote span.
+func HTTPToContext(options ...Option) kithttp.RequestFunc {
+	cfg := newConfig(options)
+
+	return func(ctx context.Context, req *http.Request) context.Context {
+		return cfg.Propagators.Extract(ctx, propagation.HeaderCarrier(req.Header))
+	}
+}
+
+// ContextToGRPC returns a Go kit gRPC ClientRequestFunc injecting the span
+// context of the context into the metadata of the outgoing request. Use it
+// as a before function of a Go kit gRPC client, e.g. with
+// kitgrpc.ClientBefore.
+func ContextToGRPC(options ...Option) kitgrpc.ClientRequestFunc {
+	cfg := newConfig(options)
+
+	return func(ctx context.Context, md *metadata.MD) context.Context {
+		cfg.Propagators.Inject(ctx, &metadataSupplier{metadata: md})
+		return ctx
+	}
+}
+
+// GRPCToContext returns a Go kit gRPC ServerRequestFunc extracting the span
+// context from the metadata of the incoming request into the context. Use
+// it as a before function of a Go kit gRPC server, e.g. with
+// kitgrpc.ServerBefore, so the spans of EndpointMiddleware are children of
+// the remote span.
+func GRPCToContext(options ...Option) kitgrpc.ServerRequestFunc {
+	cfg := newConfig(options)
+
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		return cfg.Propagators.Extract(ctx, &metadataSupplier{metadata: &md})
+	}
+}
+
+// metadataSupplier adapts gRPC metadata to the propagation.TextMapCarrier
+// interface.
+type metadataSupplier struct {
+	metadata *metadata.MD
+}
+
+// assert that metadataSupplier implements the TextMapCarrier interface.
+var _ propagation.TextMapCarrier = &metadataSupplier{}
+
+func (s *metadataSupplier) Get(key string) string {
+	values := s.metadata.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (s *metadataSupplier) Set(key string, value string) {
+	s.metadata.Set(key, value)
+}
+
+func (s *metadataSupplier) Keys() []string {
+	out := make([]string, 0, len(*s.metadata))
+	for key := range *s.metadata {
+		out = append(out, key)
+	}
+	return out
+}
@@ -31,6 +31,7 @@ type config struct {
 	queryObserver     gocql.QueryObserver
 	batchObserver     gocql.BatchObserver
 	connectObserver   gocql.ConnectObserver
+	statementMode     StatementMode
 }
 
 // Option applies a configuration option.
@@ -116,6 +117,16 @@ func WithConnectInstrumentation(enabled bool) Option {
 	})
 }
 
+// WithStatementMode will set how the CQL statement of queries is recorded
+// in the db.statement attribute of their spans. Spans are named after the
+// operation and the table of the statement, and the statement is never
+// recorded in the attributes of metrics. Defaults to StatementKeep.
+func WithStatementMode(mode StatementMode) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.statementMode = mode
+	})
+}
+
 func newConfig(options ...Option) *config {
 	cfg := &config{
 		tracerProvider:    otel.GetTracerProvider(),
@@ -123,6 +134,7 @@ func newConfig(options ...Option) *config {
 		instrumentQuery:   true,
 		instrumentBatch:   true,
 		instrumentConnect: true,
+		statementMode:     StatementKeep,
 	}
 
 	for _, apply := range options {
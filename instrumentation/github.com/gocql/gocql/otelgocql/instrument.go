@@ -35,7 +35,11 @@ type instruments struct {
 	// with the traced session.
 	connectionCount metric.Int64Counter
 
-	// latency is the sum of attempt latencies.
+	// retryCount is the number of query and batch attempts
+	// made after the first one.
+	retryCount metric.Int64Counter
+
+	// latency is the latency of a query or batch attempt.
 	latency metric.Int64Histogram
 }
 
@@ -77,9 +81,16 @@ func newInstruments(p metric.MeterProvider) *instruments {
 		log.Printf("failed to create iConnectionCount instrument, %v", err)
 	}
 
+	if instruments.retryCount, err = meter.Int64Counter(
+		"db.cassandra.retries",
+		metric.WithDescription("Number of query and batch attempts retried"),
+	); err != nil {
+		log.Printf("failed to create iRetryCount instrument, %v", err)
+	}
+
 	if instruments.latency, err = meter.Int64Histogram(
 		"db.cassandra.latency",
-		metric.WithDescription("Sum of latency to host in milliseconds"),
+		metric.WithDescription("Latency of a query or batch attempt in milliseconds"),
 		metric.WithUnit("ms"),
 	); err != nil {
 		log.Printf("failed to create iLatency instrument, %v", err)
@@ -17,6 +17,7 @@ package internal // import "go.opentelemetry.io/contrib/instrumentation/github.c
 import (
 	"log"
 	"net"
+	"strings"
 
 	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
@@ -52,6 +53,18 @@ const (
 	// made for the query in question.
 	CassQueryAttemptsKey = attribute.Key("db.cassandra.attempts")
 
+	// CassAttemptKey is the key for the span attribute describing the index
+	// of the attempt a span describes. The first attempt is number zero and
+	// retries and speculative executions have non-zero attempt numbers.
+	CassAttemptKey = attribute.Key("db.cassandra.attempt")
+
+	// CassPageKey is the key for the span attribute describing the index
+	// of the page fetched by a paged query. The first page is number zero.
+	CassPageKey = attribute.Key("db.cassandra.page")
+
+	// CassQueryName is the query operation span name, used when
+	// the operation of the statement is unknown.
+	CassQueryName = "Query"
 	// CassBatchQueryName is the batch operation span name.
 	CassBatchQueryName = "Batch Query"
 	// CassConnectName is the connect operation span name.
@@ -110,6 +123,18 @@ func CassStatement(stmt string) attribute.KeyValue {
 	return semconv.DBStatement(stmt)
 }
 
+// CassOperation returns the operation of the statement made to the
+// cassandra database as a semconv KeyValue pair (db.operation).
+func CassOperation(operation string) attribute.KeyValue {
+	return semconv.DBOperation(operation)
+}
+
+// CassTable returns the table the statement made to the cassandra
+// database operates on as a semconv KeyValue pair (db.cassandra.table).
+func CassTable(table string) attribute.KeyValue {
+	return semconv.DBCassandraTable(table)
+}
+
 // CassBatchQueryOperation returns the batch query operation
 // as a semconv KeyValue pair (db.operation). This is used in lieu of a
 // db.statement, which is not feasible to include in a span for a batch query
@@ -157,6 +182,30 @@ func CassQueryAttempts(num int) attribute.KeyValue {
 	return CassQueryAttemptsKey.Int(num)
 }
 
+// CassAttempt returns the KeyValue pair of the index of a query or
+// batch attempt.
+func CassAttempt(attempt int) attribute.KeyValue {
+	return CassAttemptKey.Int(attempt)
+}
+
+// CassPage returns the KeyValue pair of the index of the page fetched
+// by a query.
+func CassPage(page int) attribute.KeyValue {
+	return CassPageKey.Int(page)
+}
+
+// CassConsistencyLevel returns the consistency level of a query as a
+// semconv KeyValue pair (db.cassandra.consistency_level).
+func CassConsistencyLevel(consistency string) attribute.KeyValue {
+	return semconv.DBCassandraConsistencyLevelKey.String(strings.ToLower(consistency))
+}
+
+// CassCoordinatorDC returns the data center of the coordinating node of
+// a query as a semconv KeyValue pair (db.cassandra.coordinator.dc).
+func CassCoordinatorDC(dc string) attribute.KeyValue {
+	return semconv.DBCassandraCoordinatorDC(dc)
+}
+
 // HostOrIP returns a KeyValue pair for the hostname
 // retrieved from gocql.HostInfo.HostnameAndPort(). If the hostname
 // is returned as a resolved IP address (as is the case for localhost),
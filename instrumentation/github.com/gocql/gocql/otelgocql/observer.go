@@ -16,12 +16,12 @@ package otelgocql // import "go.opentelemetry.io/contrib/instrumentation/github.
 
 import (
 	"context"
-	"time"
 
 	"github.com/gocql/gocql"
 
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gocql/gocql/otelgocql/internal"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -29,10 +29,11 @@ import (
 // OTelQueryObserver implements the gocql.QueryObserver interface
 // to provide instrumentation to gocql queries.
 type OTelQueryObserver struct {
-	enabled  bool
-	observer gocql.QueryObserver
-	tracer   trace.Tracer
-	inst     *instruments
+	enabled       bool
+	observer      gocql.QueryObserver
+	tracer        trace.Tracer
+	inst          *instruments
+	statementMode StatementMode
 }
 
 // OTelBatchObserver implements the gocql.BatchObserver interface
@@ -56,23 +57,51 @@ type OTelConnectObserver struct {
 
 // ------------------------------------------ Observer Functions
 
-// ObserveQuery is called once per query, and provides instrumentation for it.
+// ObserveQuery is called once per query attempt, and provides instrumentation
+// for it. Retries, speculative executions and the page fetches of an
+// iterator are each reported as a span of their own.
 func (o *OTelQueryObserver) ObserveQuery(ctx context.Context, observedQuery gocql.ObservedQuery) {
 	if o.enabled {
 		host := observedQuery.Host
 		keyspace := observedQuery.Keyspace
 		inst := o.inst
 
-		attributes := includeKeyValues(host,
-			internal.CassKeyspace(keyspace),
-			internal.CassStatement(observedQuery.Statement),
+		stmt := summarizeStatement(observedQuery.Statement)
+		spanName := stmt.name()
+		if spanName == "" {
+			spanName = internal.CassQueryName
+		}
+		statementAttributes := []attribute.KeyValue{internal.CassKeyspace(keyspace)}
+		if stmt.operation != "" {
+			statementAttributes = append(statementAttributes, internal.CassOperation(stmt.operation))
+		}
+		if stmt.table != "" {
+			statementAttributes = append(statementAttributes, internal.CassTable(stmt.table))
+		}
+
+		attributes := includeKeyValues(host, statementAttributes...)
+		switch o.statementMode {
+		case StatementKeep:
+			attributes = append(attributes, internal.CassStatement(observedQuery.Statement))
+		case StatementStripParameters:
+			attributes = append(attributes, internal.CassStatement(stripParameters(observedQuery.Statement)))
+		}
+		attributes = append(attributes,
 			internal.CassRowsReturned(observedQuery.Rows),
 			internal.CassQueryAttempts(observedQuery.Metrics.Attempts),
+			internal.CassAttempt(observedQuery.Attempt),
+			internal.CassCoordinatorDC(host.DataCenter()),
 		)
+		if state := queryStateFromContext(ctx); state != nil {
+			attributes = append(attributes,
+				internal.CassConsistencyLevel(state.query.GetConsistency().String()),
+				internal.CassPage(state.page(observedQuery.Attempt)),
+			)
+		}
 
 		ctx, span := o.tracer.Start(
 			ctx,
-			observedQuery.Statement,
+			spanName,
 			trace.WithTimestamp(observedQuery.Start),
 			trace.WithAttributes(attributes...),
 			trace.WithSpanKind(trace.SpanKindClient),
@@ -81,26 +110,24 @@ func (o *OTelQueryObserver) ObserveQuery(ctx context.Context, observedQuery gocq
 		if observedQuery.Err != nil {
 			attributes = includeKeyValues(
 				host,
-				internal.CassKeyspace(keyspace),
-				internal.CassStatement(observedQuery.Statement),
-				internal.CassErrMsg(observedQuery.Err.Error()),
+				append(statementAttributes, internal.CassErrMsg(observedQuery.Err.Error()))...,
 			)
 			span.SetAttributes(internal.CassErrMsg(observedQuery.Err.Error()))
+			span.SetStatus(codes.Error, observedQuery.Err.Error())
 			inst.queryCount.Add(ctx, 1, metric.WithAttributes(attributes...))
 		} else {
-			attributes = includeKeyValues(
-				host,
-				internal.CassKeyspace(keyspace),
-				internal.CassStatement(observedQuery.Statement),
-			)
+			attributes = includeKeyValues(host, statementAttributes...)
 			inst.queryCount.Add(ctx, 1, metric.WithAttributes(attributes...))
 		}
 
 		span.End(trace.WithTimestamp(observedQuery.End))
 
-		o := metric.WithAttributes(includeKeyValues(host, internal.CassKeyspace(keyspace))...)
+		o := metric.WithAttributes(includeKeyValues(host, statementAttributes...)...)
+		if observedQuery.Attempt > 0 {
+			inst.retryCount.Add(ctx, 1, o)
+		}
 		inst.queryRows.Record(ctx, int64(observedQuery.Rows), o)
-		inst.latency.Record(ctx, nanoToMilliseconds(observedQuery.Metrics.TotalLatency), o)
+		inst.latency.Record(ctx, observedQuery.End.Sub(observedQuery.Start).Milliseconds(), o)
 	}
 
 	if o.observer != nil {
@@ -108,7 +135,8 @@ func (o *OTelQueryObserver) ObserveQuery(ctx context.Context, observedQuery gocq
 	}
 }
 
-// ObserveBatch is called once per batch query, and provides instrumentation for it.
+// ObserveBatch is called once per batch query attempt, and provides
+// instrumentation for it.
 func (o *OTelBatchObserver) ObserveBatch(ctx context.Context, observedBatch gocql.ObservedBatch) {
 	if o.enabled {
 		host := observedBatch.Host
@@ -119,6 +147,8 @@ func (o *OTelBatchObserver) ObserveBatch(ctx context.Context, observedBatch gocq
 			internal.CassKeyspace(keyspace),
 			internal.CassBatchQueryOperation(),
 			internal.CassBatchQueries(len(observedBatch.Statements)),
+			internal.CassAttempt(observedBatch.Attempt),
+			internal.CassCoordinatorDC(host.DataCenter()),
 		)
 
 		ctx, span := o.tracer.Start(
@@ -136,6 +166,7 @@ func (o *OTelBatchObserver) ObserveBatch(ctx context.Context, observedBatch gocq
 				internal.CassErrMsg(observedBatch.Err.Error()),
 			)
 			span.SetAttributes(internal.CassErrMsg(observedBatch.Err.Error()))
+			span.SetStatus(codes.Error, observedBatch.Err.Error())
 			inst.batchCount.Add(ctx, 1, metric.WithAttributes(attributes...))
 		} else {
 			attributes = includeKeyValues(host, internal.CassKeyspace(keyspace))
@@ -145,7 +176,10 @@ func (o *OTelBatchObserver) ObserveBatch(ctx context.Context, observedBatch gocq
 		span.End(trace.WithTimestamp(observedBatch.End))
 
 		o := metric.WithAttributes(includeKeyValues(host, internal.CassKeyspace(keyspace))...)
-		inst.latency.Record(ctx, nanoToMilliseconds(observedBatch.Metrics.TotalLatency), o)
+		if observedBatch.Attempt > 0 {
+			inst.retryCount.Add(ctx, 1, o)
+		}
+		inst.latency.Record(ctx, observedBatch.End.Sub(observedBatch.Start).Milliseconds(), o)
 	}
 
 	if o.observer != nil {
@@ -203,8 +237,3 @@ func includeKeyValues(host *gocql.HostInfo, values ...attribute.KeyValue) []attr
 	}
 	return append(connectionLevelAttributes, values...)
 }
-
-// nanoToMilliseconds converts nanoseconds to milliseconds.
-func nanoToMilliseconds(ns int64) int64 {
-	return ns / int64(time.Millisecond)
-}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgocql // import "go.opentelemetry.io/contrib/instrumentation/github.com/gocql/gocql/otelgocql"
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gocql/gocql"
+)
+
+// queryStateKey is the context key of the queryState of a traced query.
+type queryStateKey struct{}
+
+// queryState tracks a query returned by TraceQuery across the attempts
+// and page fetches reported to the query observer.
+type queryState struct {
+	query *gocql.Query
+
+	mu    sync.Mutex
+	pages int
+}
+
+// TraceQuery returns a copy of q which lets the query observer of the
+// session record the consistency level of every attempt of the query and
+// the index of every page fetched by its iterator.
+//
+// The gocql.QueryObserver interface does not provide this information on
+// its own, so queries that are not passed to TraceQuery are reported
+// without it. The returned query is meant to be executed once; its page
+// index keeps growing when it is executed again.
+func TraceQuery(q *gocql.Query) *gocql.Query {
+	state := &queryState{}
+	traced := q.WithContext(context.WithValue(q.Context(), queryStateKey{}, state))
+	state.query = traced
+	return traced
+}
+
+// queryStateFromContext returns the queryState stored in ctx by
+// TraceQuery, or nil if there is none.
+func queryStateFromContext(ctx context.Context) *queryState {
+	state, _ := ctx.Value(queryStateKey{}).(*queryState)
+	return state
+}
+
+// page returns the index of the page fetched by an attempt. Every page
+// is fetched by a new copy of the query, and gocql numbers the attempts of
+// each copy in the order they complete, so attempt zero is always the
+// first one observed for a page.
+func (s *queryState) page(attempt int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if attempt == 0 {
+		s.pages++
+	}
+	return s.pages - 1
+}
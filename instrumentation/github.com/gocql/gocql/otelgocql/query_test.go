@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgocql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceQuery(t *testing.T) {
+	assert.Nil(t, queryStateFromContext(context.Background()))
+
+	q := TraceQuery((&gocql.Query{}).Consistency(gocql.LocalQuorum))
+	state := queryStateFromContext(q.Context())
+	require.NotNil(t, state)
+	assert.Same(t, q, state.query)
+	assert.Equal(t, gocql.LocalQuorum, state.query.GetConsistency())
+
+	// Consistency changes made after TraceQuery, for instance by a
+	// downgrading retry policy, are seen by the observer.
+	q.SetConsistency(gocql.One)
+	assert.Equal(t, gocql.One, state.query.GetConsistency())
+}
+
+func TestQueryStatePage(t *testing.T) {
+	state := &queryState{}
+	attempts := []int{0, 1, 0, 0, 1, 2}
+	pages := []int{0, 0, 1, 2, 2, 2}
+	for i, attempt := range attempts {
+		assert.Equal(t, pages[i], state.page(attempt), "attempt %d", i)
+	}
+}
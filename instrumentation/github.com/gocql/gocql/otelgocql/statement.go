@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgocql // import "go.opentelemetry.io/contrib/instrumentation/github.com/gocql/gocql/otelgocql"
+
+import (
+	"strings"
+)
+
+// StatementMode defines how the CQL statement of a query is recorded in the
+// db.statement attribute of its span. The statement is never recorded in
+// the attributes of metrics.
+type StatementMode int
+
+const (
+	// StatementKeep records the statement as is.
+	StatementKeep StatementMode = iota
+	// StatementStripParameters records the statement with its literal
+	// values replaced by "?".
+	StatementStripParameters
+	// StatementDrop does not record the statement.
+	StatementDrop
+)
+
+// cqlStatement is the summary of a CQL statement.
+type cqlStatement struct {
+	// operation is the first keyword of the statement, in upper case.
+	operation string
+	// table is the table the statement operates on, if any.
+	table string
+}
+
+// name returns the low-cardinality name of the statement, e.g. "SELECT users".
+func (s cqlStatement) name() string {
+	if s.table == "" {
+		return s.operation
+	}
+	return s.operation + " " + s.table
+}
+
+// summarizeStatement returns the operation and the table of the CQL
+// statement stmt.
+func summarizeStatement(stmt string) cqlStatement {
+	tokens := tokenize(stmt)
+	if len(tokens) == 0 {
+		return cqlStatement{}
+	}
+
+	s := cqlStatement{operation: strings.ToUpper(tokens[0])}
+	switch s.operation {
+	case "SELECT", "DELETE":
+		s.table = tokenAfter(tokens, "FROM")
+	case "INSERT":
+		s.table = tokenAfter(tokens, "INTO")
+	case "UPDATE":
+		s.table = tokenAt(tokens, 1)
+	case "TRUNCATE":
+		s.table = tokenAt(tokens, 1)
+		if strings.EqualFold(s.table, "TABLE") || strings.EqualFold(s.table, "COLUMNFAMILY") {
+			s.table = tokenAt(tokens, 2)
+		}
+	case "CREATE", "ALTER", "DROP":
+		if len(tokens) > 1 && (strings.EqualFold(tokens[1], "TABLE") || strings.EqualFold(tokens[1], "COLUMNFAMILY")) {
+			s.table = tableAfterIfExists(tokens[2:])
+		}
+	case "BEGIN":
+		s.operation = "BATCH"
+	}
+	s.table = strings.ReplaceAll(s.table, `"`, "")
+	return s
+}
+
+// tokenAfter returns the token following the first keyword in tokens, or an
+// empty string.
+func tokenAfter(tokens []string, keyword string) string {
+	for i, token := range tokens {
+		if strings.EqualFold(token, keyword) {
+			return tokenAt(tokens, i+1)
+		}
+	}
+	return ""
+}
+
+// tokenAt returns the token at index i of tokens, or an empty string.
+func tokenAt(tokens []string, i int) string {
+	if i < len(tokens) {
+		return tokens[i]
+	}
+	return ""
+}
+
+// tableAfterIfExists returns the first token of tokens, skipping a leading
+// IF EXISTS or IF NOT EXISTS clause.
+func tableAfterIfExists(tokens []string) string {
+	if len(tokens) > 0 && strings.EqualFold(tokens[0], "IF") {
+		for i, token := range tokens {
+			if strings.EqualFold(token, "EXISTS") {
+				return tokenAt(tokens, i+1)
+			}
+		}
+	}
+	return tokenAt(tokens, 0)
+}
+
+// tokenize splits the CQL statement stmt into its keywords and identifiers,
+// skipping literals, comments and punctuation.
+func tokenize(stmt string) []string {
+	var tokens []string
+	for i := 0; i < len(stmt); {
+		c := stmt[i]
+		switch {
+		case isIdentifierStart(c) || c == '"':
+			start := i
+			i = skipIdentifier(stmt, i)
+			tokens = append(tokens, stmt[start:i])
+		default:
+			if end := skipLiteralOrComment(stmt, i); end > i {
+				i = end
+				continue
+			}
+			i++
+		}
+	}
+	return tokens
+}
+
+// stripParameters returns the CQL statement stmt with its string, numeric,
+// UUID and blob literals replaced by "?".
+func stripParameters(stmt string) string {
+	var b strings.Builder
+	b.Grow(len(stmt))
+	for i := 0; i < len(stmt); {
+		c := stmt[i]
+		switch {
+		case isUUID(stmt[i:]):
+			i += uuidLength
+			b.WriteByte('?')
+		case isIdentifierStart(c) || c == '"':
+			start := i
+			i = skipIdentifier(stmt, i)
+			b.WriteString(stmt[start:i])
+		case c == '\'' || isDigit(c) || strings.HasPrefix(stmt[i:], "$$"):
+			i = skipLiteralOrComment(stmt, i)
+			b.WriteByte('?')
+		default:
+			if end := skipLiteralOrComment(stmt, i); end > i {
+				// Comments are kept as is.
+				b.WriteString(stmt[i:end])
+				i = end
+				continue
+			}
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// skipLiteralOrComment returns the index following the literal or the
+// comment starting at index i of stmt, or i if there is none.
+func skipLiteralOrComment(stmt string, i int) int {
+	rest := stmt[i:]
+	switch {
+	case rest[0] == '\'':
+		return skipQuoted(stmt, i, '\'')
+	case strings.HasPrefix(rest, "$$"):
+		if end := strings.Index(rest[2:], "$$"); end >= 0 {
+			return i + 2 + end + 2
+		}
+		return len(stmt)
+	case isDigit(rest[0]):
+		// Numbers, UUIDs and blobs are made of letters, digits, dashes
+		// and dots.
+		for i < len(stmt) && (isIdentifierPart(stmt[i]) || stmt[i] == '-' || stmt[i] == '.') {
+			i++
+		}
+		return i
+	case strings.HasPrefix(rest, "--"), strings.HasPrefix(rest, "//"):
+		if end := strings.IndexByte(rest, '\n'); end >= 0 {
+			return i + end
+		}
+		return len(stmt)
+	case strings.HasPrefix(rest, "/*"):
+		if end := strings.Index(rest[2:], "*/"); end >= 0 {
+			return i + 2 + end + 2
+		}
+		return len(stmt)
+	}
+	return i
+}
+
+// skipIdentifier returns the index following the identifier starting at
+// index i of stmt. Identifiers may be quoted and qualified by a keyspace.
+func skipIdentifier(stmt string, i int) int {
+	for i < len(stmt) {
+		switch c := stmt[i]; {
+		case c == '"':
+			i = skipQuoted(stmt, i, '"')
+		case isIdentifierPart(c):
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// uuidLength is the length of the UUID literals, e.g.
+// 123e4567-e89b-12d3-a456-426614174000.
+const uuidLength = 36
+
+// isUUID returns whether s starts with a UUID literal.
+func isUUID(s string) bool {
+	if len(s) < uuidLength || (len(s) > uuidLength && isIdentifierPart(s[uuidLength])) {
+		return false
+	}
+	for i := 0; i < uuidLength; i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHexDigit(s[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// skipQuoted returns the index following the string quoted by quote starting
+// at index i of stmt. Quotes are escaped by doubling them.
+func skipQuoted(stmt string, i int, quote byte) int {
+	for i++; i < len(stmt); i++ {
+		if stmt[i] != quote {
+			continue
+		}
+		if i+1 < len(stmt) && stmt[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(stmt)
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || isDigit(c) || c == '.'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
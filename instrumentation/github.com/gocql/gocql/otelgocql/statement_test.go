@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgocql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeStatement(t *testing.T) {
+	testCases := []struct {
+		stmt      string
+		operation string
+		table     string
+		name      string
+	}{
+		{"SELECT * FROM users WHERE id = ?", "SELECT", "users", "SELECT users"},
+		{"select id, title from ks.users", "SELECT", "ks.users", "SELECT ks.users"},
+		{`SELECT "Title" FROM "MyKeyspace"."Users"`, "SELECT", "MyKeyspace.Users", "SELECT MyKeyspace.Users"},
+		{"insert into test_table (id, title) values (?, ?)", "INSERT", "test_table", "INSERT test_table"},
+		{"INSERT INTO users(id) VALUES ('from')", "INSERT", "users", "INSERT users"},
+		{"UPDATE users SET title = 'x' WHERE id = 1", "UPDATE", "users", "UPDATE users"},
+		{"DELETE title FROM users WHERE id = ?", "DELETE", "users", "DELETE users"},
+		{"TRUNCATE TABLE users", "TRUNCATE", "users", "TRUNCATE users"},
+		{"truncate users", "TRUNCATE", "users", "TRUNCATE users"},
+		{"CREATE TABLE IF NOT EXISTS users (id uuid PRIMARY KEY)", "CREATE", "users", "CREATE users"},
+		{"DROP TABLE IF EXISTS users", "DROP", "users", "DROP users"},
+		{"ALTER TABLE users ADD title text", "ALTER", "users", "ALTER users"},
+		{"CREATE KEYSPACE ks WITH replication = {'class': 'SimpleStrategy'}", "CREATE", "", "CREATE"},
+		{"BEGIN BATCH INSERT INTO users (id) VALUES (?); APPLY BATCH", "BATCH", "", "BATCH"},
+		{"USE ks", "USE", "", "USE"},
+		{"/* comment */ SELECT * FROM users", "SELECT", "users", "SELECT users"},
+		{"-- comment\nSELECT * FROM users", "SELECT", "users", "SELECT users"},
+		{"", "", "", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.stmt, func(t *testing.T) {
+			stmt := summarizeStatement(tc.stmt)
+			assert.Equal(t, tc.operation, stmt.operation)
+			assert.Equal(t, tc.table, stmt.table)
+			assert.Equal(t, tc.name, stmt.name())
+		})
+	}
+}
+
+func TestStripParameters(t *testing.T) {
+	testCases := []struct {
+		stmt     string
+		expected string
+	}{
+		{"SELECT * FROM users WHERE id = ?", "SELECT * FROM users WHERE id = ?"},
+		{"SELECT * FROM users WHERE name = 'it''s' AND age > 42", "SELECT * FROM users WHERE name = ? AND age > ?"},
+		{"SELECT * FROM users WHERE id = 123e4567-e89b-12d3-a456-426614174000", "SELECT * FROM users WHERE id = ?"},
+		{"SELECT * FROM users WHERE id = e7a8f4c2-e89b-12d3-a456-426614174000", "SELECT * FROM users WHERE id = ?"},
+		{"INSERT INTO users (id, data, score) VALUES (1, 0xcafe, 1.5e10)", "INSERT INTO users (id, data, score) VALUES (?, ?, ?)"},
+		{"UPDATE users SET bio = $$multi\nline$$ WHERE user1 = :id", "UPDATE users SET bio = ? WHERE user1 = :id"},
+		{`SELECT "it's" FROM users LIMIT 10`, `SELECT "it's" FROM users LIMIT ?`},
+		{"SELECT * FROM users /* id = 'x' */", "SELECT * FROM users /* id = 'x' */"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.stmt, func(t *testing.T) {
+			assert.Equal(t, tc.expected, stripParameters(tc.stmt))
+		})
+	}
+}
@@ -94,8 +94,10 @@ func TestQuery(t *testing.T) {
 	// Verify attributes are correctly added to the spans. Omit the one local span
 	for _, span := range spans[0 : len(spans)-1] {
 		switch span.Name() {
-		case insertStmt:
+		case "INSERT " + tableName:
 			assert.Contains(t, span.Attributes(), semconv.DBStatement(insertStmt))
+			assert.Contains(t, span.Attributes(), semconv.DBOperation("INSERT"))
+			assert.Contains(t, span.Attributes(), semconv.DBCassandraTable(tableName))
 			assert.Equal(t, parentSpan.SpanContext().SpanID().String(), span.Parent().SpanID().String())
 		default:
 			t.Fatalf("unexpected span name %s", span.Name())
@@ -109,11 +111,69 @@ func TestQuery(t *testing.T) {
 	require.Len(t, rm.ScopeMetrics, 1)
 	sm := rm.ScopeMetrics[0]
 	assertScope(t, sm)
-	assertQueriesMetric(t, 1, insertStmt, requireMetric(t, "db.cassandra.queries", sm.Metrics))
+	assertQueriesMetric(t, 1, "INSERT", tableName, requireMetric(t, "db.cassandra.queries", sm.Metrics))
 	assertRowsMetric(t, 1, requireMetric(t, "db.cassandra.rows", sm.Metrics))
 	assertLatencyMetric(t, 1, requireMetric(t, "db.cassandra.latency", sm.Metrics))
 }
 
+func TestPagedQuery(t *testing.T) {
+	defer afterEach(t)
+	cluster := getCluster()
+	sr := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	ctx, parentSpan := tracerProvider.Tracer(internal.InstrumentationName).Start(context.Background(), "gocql-test")
+
+	session, err := otelgocql.NewSessionWithTracing(
+		ctx,
+		cluster,
+		otelgocql.WithTracerProvider(tracerProvider),
+		otelgocql.WithConnectInstrumentation(false),
+	)
+	require.NoError(t, err)
+	defer session.Close()
+	require.NoError(t, session.AwaitSchemaAgreement(ctx))
+
+	// Insert the rows without instrumentation.
+	plainSession, err := cluster.CreateSession()
+	require.NoError(t, err)
+	defer plainSession.Close()
+	insertStmt := fmt.Sprintf("insert into %s (id, title) values (?, ?)", tableName)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, plainSession.Query(insertStmt, gocql.TimeUUID(), fmt.Sprintf("title-%d", i)).Exec())
+	}
+
+	selectStmt := fmt.Sprintf("select id, title from %s", tableName)
+	query := session.Query(selectStmt).WithContext(ctx).Consistency(gocql.One).PageSize(1)
+	iter := otelgocql.TraceQuery(query).Iter()
+	var (
+		rows  int
+		id    gocql.UUID
+		title string
+	)
+	for iter.Scan(&id, &title) {
+		rows++
+	}
+	require.NoError(t, iter.Close())
+	assert.Equal(t, 3, rows)
+
+	parentSpan.End()
+
+	// total spans:
+	// 1 span for each page, at least 3 as every page holds a single row
+	// 1 span created in test
+	spans := sr.Ended()
+	require.GreaterOrEqual(t, len(spans), 4)
+	for i, span := range spans[:len(spans)-1] {
+		assert.Equal(t, "SELECT "+tableName, span.Name())
+		assert.Equal(t, parentSpan.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Contains(t, span.Attributes(), internal.CassPage(i))
+		assert.Contains(t, span.Attributes(), internal.CassAttempt(0))
+		assert.Contains(t, span.Attributes(), semconv.DBCassandraConsistencyLevelOne)
+		assertConnectionLevelAttributes(t, span)
+	}
+}
+
 func TestBatch(t *testing.T) {
 	defer afterEach(t)
 	cluster := getCluster()
@@ -274,7 +334,7 @@ func getMetric(name string, metrics []metricdata.Metrics) (metricdata.Metrics, b
 	return metricdata.Metrics{}, false
 }
 
-func assertQueriesMetric(t *testing.T, value int64, stmt string, m metricdata.Metrics) {
+func assertQueriesMetric(t *testing.T, value int64, operation, table string, m metricdata.Metrics) {
 	assert.Equal(t, "db.cassandra.queries", m.Name)
 	assert.Equal(t, "Number queries executed", m.Description)
 	require.IsType(t, m.Data, metricdata.Sum[int64]{})
@@ -292,8 +352,11 @@ func assertQueriesMetric(t *testing.T, value int64, stmt string, m metricdata.Me
 		internal.CassHostID("test-id"),
 		internal.CassHostState("UP"),
 		internal.CassKeyspace(keyspace),
-		internal.CassStatement(stmt),
+		internal.CassOperation(operation),
+		internal.CassTable(table),
 	}, dPt.Attributes)
+	_, ok := dPt.Attributes.Value(semconv.DBStatementKey)
+	assert.False(t, ok, "unexpected db.statement attribute")
 }
 
 func assertBatchQueriesMetric(t *testing.T, value int64, m metricdata.Metrics) {
@@ -358,7 +421,7 @@ func assertRowsMetric(t *testing.T, count uint64, m metricdata.Metrics) {
 
 func assertLatencyMetric(t *testing.T, count uint64, m metricdata.Metrics) {
 	assert.Equal(t, "db.cassandra.latency", m.Name)
-	assert.Equal(t, "Sum of latency to host in milliseconds", m.Description)
+	assert.Equal(t, "Latency of a query or batch attempt in milliseconds", m.Description)
 	assert.Equal(t, "ms", m.Unit)
 	require.IsType(t, m.Data, metricdata.Histogram[int64]{})
 	data := m.Data.(metricdata.Histogram[int64])
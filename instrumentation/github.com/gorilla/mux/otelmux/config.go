@@ -17,6 +17,7 @@ package otelmux // import "go.opentelemetry.io/contrib/instrumentation/github.co
 import (
 	"net/http"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
@@ -24,6 +25,7 @@ import (
 // config is used to configure the mux middleware.
 type config struct {
 	TracerProvider    oteltrace.TracerProvider
+	MeterProvider     metric.MeterProvider
 	Propagators       propagation.TextMapPropagator
 	spanNameFormatter func(string, *http.Request) string
 	PublicEndpoint    bool
@@ -82,6 +84,16 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithSpanNameFormatter specifies a function to use for generating a custom span
 // name. By default, the route name (path template or regexp) is used. The route
 // name is provided so you can use it in the span name without needing to
@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmux // import "go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+
+// Generate the server instruments and withoutCancel:
+//go:generate gotmpl --body=../../../../../internal/shared/servermetric/metric.go.tmpl "--data={ \"pkg\": \"otelmux\" }" --out=metric.go
+//go:generate gotmpl --body=../../../../../internal/shared/withoutcancel/withoutcancel.go.tmpl "--data={ \"pkg\": \"otelmux\" }" --out=withoutcancel.go
+//go:generate gotmpl --body=../../../../../internal/shared/withoutcancel/withoutcancel_test.go.tmpl "--data={ \"pkg\": \"otelmux\" }" --out=withoutcancel_test.go
@@ -121,6 +121,49 @@ func TestHTTPClientRequestRequired(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestHTTPClientRequestMetrics(t *testing.T) {
+	req := &http.Request{
+		Method: http.MethodPost,
+		URL: &url.URL{
+			Scheme: "http",
+			Host:   "127.0.0.1:8080",
+			Path:   "/resource",
+		},
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"User-Agent": []string{"Go-http-client/1.1"},
+		},
+		ContentLength: 128,
+	}
+
+	got := HTTPClientRequestMetrics(req)
+	assert.Equal(t, 4, cap(got), "slice capacity")
+	assert.Equal(
+		t,
+		[]attribute.KeyValue{
+			attribute.String("http.method", "POST"),
+			attribute.String("http.flavor", "1.1"),
+			attribute.String("net.peer.name", "127.0.0.1"),
+			attribute.Int("net.peer.port", 8080),
+		},
+		got,
+	)
+}
+
+func TestHTTPClientRequestMetricsRequired(t *testing.T) {
+	req := new(http.Request)
+	var got []attribute.KeyValue
+	assert.NotPanics(t, func() { got = HTTPClientRequestMetrics(req) })
+	want := []attribute.KeyValue{
+		attribute.String("http.method", "GET"),
+		attribute.String("http.flavor", ""),
+		attribute.String("net.peer.name", ""),
+	}
+	assert.Equal(t, want, got)
+}
+
 func TestHTTPServerRequest(t *testing.T) {
 	got := make(chan *http.Request, 1)
 	handler := func(w http.ResponseWriter, r *http.Request) {
@@ -186,6 +229,36 @@ func TestHTTPServerName(t *testing.T) {
 	assert.Contains(t, got, attribute.Int("net.host.port", port))
 }
 
+func TestHTTPServerRequestMetrics(t *testing.T) {
+	req := &http.Request{
+		Method:     http.MethodPost,
+		Host:       "127.0.0.1:8080",
+		RemoteAddr: "127.0.0.2:41234",
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"User-Agent":      []string{"Go-http-client/1.1"},
+			"X-Forwarded-For": []string{"127.0.0.5"},
+		},
+	}
+
+	got := HTTPServerRequestMetrics("", req)
+	assert.Equal(t, 4, cap(got), "slice capacity")
+	assert.Equal(t,
+		[]attribute.KeyValue{
+			attribute.String("http.method", "POST"),
+			attribute.String("http.scheme", "http"),
+			attribute.String("net.host.name", "127.0.0.1"),
+			attribute.Int("net.host.port", 8080),
+		},
+		got)
+
+	got = HTTPServerRequestMetrics("test.semconv.server", req)
+	assert.Contains(t, got, attribute.String("net.host.name", "test.semconv.server"))
+	assert.Contains(t, got, attribute.Int("net.host.port", 8080))
+}
+
 func TestHTTPServerRequestFailsGracefully(t *testing.T) {
 	req := new(http.Request)
 	var got []attribute.KeyValue
@@ -0,0 +1,82 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/servermetric/metric.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmux // import "go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Names of the instruments recorded by the instrumentation. They match the
+// server instruments of go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp.
+const (
+	serverDuration       = "http.server.duration"                // Incoming end to end duration, milliseconds
+	serverRequestSize    = "http.server.request_content_length"  // Incoming request bytes total
+	serverResponseSize   = "http.server.response_content_length" // Outgoing response bytes total
+	serverActiveRequests = "http.server.active_requests"         // Incoming requests currently being served
+)
+
+type instruments struct {
+	duration       metric.Float64Histogram
+	requestSize    metric.Int64Counter
+	responseSize   metric.Int64Counter
+	activeRequests metric.Int64UpDownCounter
+}
+
+func newInstruments(meter metric.Meter) instruments {
+	var (
+		i   instruments
+		err error
+	)
+
+	i.duration, err = meter.Float64Histogram(
+		serverDuration,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of inbound HTTP requests."),
+	)
+	handleErr(err)
+
+	i.requestSize, err = meter.Int64Counter(
+		serverRequestSize,
+		metric.WithUnit("By"),
+		metric.WithDescription("Measures the size of HTTP request content."),
+	)
+	handleErr(err)
+
+	i.responseSize, err = meter.Int64Counter(
+		serverResponseSize,
+		metric.WithUnit("By"),
+		metric.WithDescription("Measures the size of HTTP response content."),
+	)
+	handleErr(err)
+
+	i.activeRequests, err = meter.Int64UpDownCounter(
+		serverActiveRequests,
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Measures the number of concurrent HTTP requests that are currently in-flight."),
+	)
+	handleErr(err)
+
+	return i
+}
+
+func handleErr(err error) {
+	if err != nil {
+		otel.Handle(err)
+	}
+}
@@ -18,12 +18,14 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/felixge/httpsnoop"
 	"github.com/gorilla/mux"
 
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux/internal/semconvutil"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
@@ -31,10 +33,13 @@ import (
 
 const (
 	tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	meterName  = tracerName
 )
 
 // Middleware sets up a handler to start tracing the incoming
-// requests.  The service parameter should describe the name of the
+// requests and recording their metrics.  The metrics have the http.route
+// attribute of the path template of the matched route instead of the
+// request path.  The service parameter should describe the name of the
 // (virtual) server handling the request.
 func Middleware(service string, opts ...Option) mux.MiddlewareFunc {
 	cfg := config{}
@@ -54,11 +59,19 @@ func Middleware(service string, opts ...Option) mux.MiddlewareFunc {
 	if cfg.spanNameFormatter == nil {
 		cfg.spanNameFormatter = defaultSpanNameFunc
 	}
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = otel.GetMeterProvider()
+	}
+	inst := newInstruments(cfg.MeterProvider.Meter(
+		meterName,
+		metric.WithInstrumentationVersion(Version()),
+	))
 
 	return func(handler http.Handler) http.Handler {
 		return traceware{
 			service:           service,
 			tracer:            tracer,
+			inst:              inst,
 			propagators:       cfg.Propagators,
 			handler:           handler,
 			spanNameFormatter: cfg.spanNameFormatter,
@@ -71,6 +84,7 @@ func Middleware(service string, opts ...Option) mux.MiddlewareFunc {
 type traceware struct {
 	service           string
 	tracer            trace.Tracer
+	inst              instruments
 	propagators       propagation.TextMapPropagator
 	handler           http.Handler
 	spanNameFormatter func(string, *http.Request) string
@@ -82,6 +96,7 @@ type recordingResponseWriter struct {
 	writer  http.ResponseWriter
 	written bool
 	status  int
+	size    int64
 }
 
 var rrwPool = &sync.Pool{
@@ -94,13 +109,16 @@ func getRRW(writer http.ResponseWriter) *recordingResponseWriter {
 	rrw := rrwPool.Get().(*recordingResponseWriter)
 	rrw.written = false
 	rrw.status = http.StatusOK
+	rrw.size = 0
 	rrw.writer = httpsnoop.Wrap(writer, httpsnoop.Hooks{
 		Write: func(next httpsnoop.WriteFunc) httpsnoop.WriteFunc {
 			return func(b []byte) (int, error) {
 				if !rrw.written {
 					rrw.written = true
 				}
-				return next(b)
+				n, err := next(b)
+				rrw.size += int64(n)
+				return n, err
 			}
 		},
 		WriteHeader: func(next httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
@@ -127,6 +145,7 @@ func defaultSpanNameFunc(routeName string, _ *http.Request) string { return rout
 // ServeHTTP implements the http.Handler interface. It does the actual
 // tracing of the request.
 func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestStartTime := time.Now()
 	ctx := tw.propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
 	routeStr := ""
 	route := mux.CurrentRoute(r)
@@ -154,11 +173,13 @@ func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	metricAttrs := semconvutil.HTTPServerRequestMetrics(tw.service, r)
 	if routeStr == "" {
 		routeStr = fmt.Sprintf("HTTP %s route not found", r.Method)
 	} else {
 		rAttr := semconv.HTTPRoute(routeStr)
 		opts = append(opts, trace.WithAttributes(rAttr))
+		metricAttrs = append(metricAttrs, rAttr)
 	}
 	spanName := tw.spanNameFormatter(routeStr, r)
 	ctx, span := tw.tracer.Start(ctx, spanName, opts...)
@@ -166,9 +187,25 @@ func (tw traceware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	r2 := r.WithContext(ctx)
 	rrw := getRRW(w)
 	defer putRRW(rrw)
+
+	activeAttrs := metric.WithAttributes(metricAttrs...)
+	tw.inst.activeRequests.Add(ctx, 1, activeAttrs)
+	defer tw.inst.activeRequests.Add(withoutCancel(ctx), -1, activeAttrs)
+
 	tw.handler.ServeHTTP(rrw.writer, r2)
 	if rrw.status > 0 {
 		span.SetAttributes(semconv.HTTPStatusCode(rrw.status))
+		metricAttrs = append(metricAttrs, semconv.HTTPStatusCode(rrw.status))
 	}
 	span.SetStatus(semconvutil.HTTPServerStatus(rrw.status))
+
+	ctx, o := withoutCancel(ctx), metric.WithAttributes(metricAttrs...)
+	if r.ContentLength > 0 {
+		tw.inst.requestSize.Add(ctx, r.ContentLength, o)
+	}
+	if rrw.size > 0 {
+		tw.inst.responseSize.Add(ctx, rrw.size, o)
+	}
+	elapsedTime := float64(time.Since(requestStartTime)) / float64(time.Millisecond)
+	tw.inst.duration.Record(ctx, elapsedTime, o)
 }
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -29,6 +30,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
@@ -293,3 +295,77 @@ func TestWithPublicEndpointFn(t *testing.T) {
 		})
 	}
 }
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	// The client goes away while the request is served, which cancels the
+	// context of the request.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	router := mux.NewRouter()
+	router.Use(otelmux.Middleware("foobar", otelmux.WithMeterProvider(provider)))
+	router.HandleFunc("/user/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		cancel()
+		_, _ = w.Write([]byte("ok"))
+	}).Methods(http.MethodPost)
+
+	r := httptest.NewRequest("POST", "/user/123", strings.NewReader("hello")).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux", serverMetrics{
+		route:        "/user/{id}",
+		status:       http.StatusOK,
+		requestSize:  5,
+		responseSize: 2,
+	})
+}
+
+func TestMetricsUnmatchedRoute(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	// The middleware of the router only serves the matched routes, the
+	// router has to be wrapped to serve the others.
+	router := mux.NewRouter()
+	router.HandleFunc("/user/{id}", ok)
+	h := otelmux.Middleware("foobar", otelmux.WithMeterProvider(provider))(router)
+
+	r := httptest.NewRequest("POST", "/book/123", strings.NewReader("hello"))
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux", serverMetrics{
+		status:       http.StatusNotFound,
+		requestSize:  5,
+		responseSize: int64(w.Body.Len()),
+	})
+}
+
+func TestMetricsErrorStatus(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	router := mux.NewRouter()
+	router.Use(otelmux.Middleware("foobar", otelmux.WithMeterProvider(provider)))
+	router.HandleFunc("/user/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "error", http.StatusInternalServerError)
+	}).Methods(http.MethodPost)
+
+	r := httptest.NewRequest("POST", "/user/123", strings.NewReader("hello"))
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux", serverMetrics{
+		route:        "/user/{id}",
+		status:       http.StatusInternalServerError,
+		requestSize:  5,
+		responseSize: int64(w.Body.Len()),
+	})
+}
@@ -0,0 +1,39 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/withoutcancel/withoutcancel.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmux // import "go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+
+import (
+	"context"
+	"time"
+)
+
+// withoutCancel returns a copy of parent that is never canceled but still
+// carries all of its values, e.g. the span of the request. It is used to
+// record measurements once the request is done, as the metric SDK drops the
+// measurements made with a canceled context.
+func withoutCancel(parent context.Context) context.Context {
+	return noCancelCtx{parent}
+}
+
+type noCancelCtx struct {
+	context.Context
+}
+
+func (noCancelCtx) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (noCancelCtx) Done() <-chan struct{}       { return nil }
+func (noCancelCtx) Err() error                  { return nil }
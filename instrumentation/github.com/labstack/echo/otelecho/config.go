@@ -17,6 +17,7 @@ package otelecho // import "go.opentelemetry.io/contrib/instrumentation/github.c
 import (
 	"github.com/labstack/echo/v4/middleware"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	oteltrace "go.opentelemetry.io/otel/trace"
 )
@@ -24,6 +25,7 @@ import (
 // config is used to configure the mux middleware.
 type config struct {
 	TracerProvider oteltrace.TracerProvider
+	MeterProvider  metric.MeterProvider
 	Propagators    propagation.TextMapPropagator
 	Skipper        middleware.Skipper
 }
@@ -60,6 +62,16 @@ func WithTracerProvider(provider oteltrace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithSkipper specifies a skipper for allowing requests to skip generating spans.
 func WithSkipper(skipper middleware.Skipper) Option {
 	return optionFunc(func(cfg *config) {
@@ -16,6 +16,7 @@ package otelecho // import "go.opentelemetry.io/contrib/instrumentation/github.c
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -24,6 +25,7 @@ import (
 
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho/internal/semconvutil"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
@@ -32,9 +34,12 @@ import (
 const (
 	tracerKey  = "otel-go-contrib-tracer-labstack-echo"
 	tracerName = "go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	meterName  = tracerName
 )
 
-// Middleware returns echo middleware which will trace incoming requests.
+// Middleware returns echo middleware which will trace incoming requests and
+// record their metrics. The metrics have the http.route attribute of the
+// path of the matched route, e.g. /user/:id, instead of the request path.
 func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 	cfg := config{}
 	for _, opt := range opts {
@@ -55,11 +60,20 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 		cfg.Skipper = middleware.DefaultSkipper
 	}
 
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = otel.GetMeterProvider()
+	}
+	inst := newInstruments(cfg.MeterProvider.Meter(
+		meterName,
+		metric.WithInstrumentationVersion(Version()),
+	))
+
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			if cfg.Skipper(c) {
 				return next(c)
 			}
+			requestStartTime := time.Now()
 
 			c.Set(tracerKey, tracer)
 			request := c.Request()
@@ -73,9 +87,11 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 				oteltrace.WithAttributes(semconvutil.HTTPServerRequest(service, request)...),
 				oteltrace.WithSpanKind(oteltrace.SpanKindServer),
 			}
+			metricAttrs := semconvutil.HTTPServerRequestMetrics(service, request)
 			if path := c.Path(); path != "" {
 				rAttr := semconv.HTTPRoute(path)
 				opts = append(opts, oteltrace.WithAttributes(rAttr))
+				metricAttrs = append(metricAttrs, rAttr)
 			}
 			spanName := c.Path()
 			if spanName == "" {
@@ -88,6 +104,10 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 			// pass the span through the request context
 			c.SetRequest(request.WithContext(ctx))
 
+			activeAttrs := metric.WithAttributes(metricAttrs...)
+			inst.activeRequests.Add(ctx, 1, activeAttrs)
+			defer inst.activeRequests.Add(withoutCancel(ctx), -1, activeAttrs)
+
 			// serve the request to the next middleware
 			err := next(c)
 			if err != nil {
@@ -100,7 +120,18 @@ func Middleware(service string, opts ...Option) echo.MiddlewareFunc {
 			span.SetStatus(semconvutil.HTTPServerStatus(status))
 			if status > 0 {
 				span.SetAttributes(semconv.HTTPStatusCode(status))
+				metricAttrs = append(metricAttrs, semconv.HTTPStatusCode(status))
+			}
+
+			ctx, o := withoutCancel(ctx), metric.WithAttributes(metricAttrs...)
+			if request.ContentLength > 0 {
+				inst.requestSize.Add(ctx, request.ContentLength, o)
+			}
+			if size := c.Response().Size; size > 0 {
+				inst.responseSize.Add(ctx, size, o)
 			}
+			elapsedTime := float64(time.Since(requestStartTime)) / float64(time.Millisecond)
+			inst.duration.Record(ctx, elapsedTime, o)
 
 			return err
 		}
@@ -17,9 +17,11 @@
 package test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/labstack/echo/v4"
@@ -30,6 +32,7 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
@@ -213,3 +216,75 @@ func TestErrorNotSwallowedByMiddleware(t *testing.T) {
 	err := h(c)
 	assert.Equal(t, assert.AnError, err)
 }
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	// The client goes away while the request is served, which cancels the
+	// context of the request.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	router := echo.New()
+	router.Use(otelecho.Middleware("foobar", otelecho.WithMeterProvider(provider)))
+	router.POST("/user/:id", func(c echo.Context) error {
+		cancel()
+		return c.String(http.StatusOK, "ok")
+	})
+
+	r := httptest.NewRequest("POST", "/user/123", strings.NewReader("hello")).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho", serverMetrics{
+		route:        "/user/:id",
+		status:       http.StatusOK,
+		requestSize:  5,
+		responseSize: 2,
+	})
+}
+
+func TestMetricsUnmatchedRoute(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	router := echo.New()
+	router.Use(otelecho.Middleware("foobar", otelecho.WithMeterProvider(provider)))
+	router.POST("/user/:id", func(c echo.Context) error { return nil })
+
+	r := httptest.NewRequest("POST", "/book/123", strings.NewReader("hello"))
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho", serverMetrics{
+		status:       http.StatusNotFound,
+		requestSize:  5,
+		responseSize: int64(w.Body.Len()),
+	})
+}
+
+func TestMetricsErrorStatus(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	router := echo.New()
+	router.Use(otelecho.Middleware("foobar", otelecho.WithMeterProvider(provider)))
+	router.POST("/user/:id", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusInternalServerError, "error")
+	})
+
+	r := httptest.NewRequest("POST", "/user/123", strings.NewReader("hello"))
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho", serverMetrics{
+		route:        "/user/:id",
+		status:       http.StatusInternalServerError,
+		requestSize:  5,
+		responseSize: int64(w.Body.Len()),
+	})
+}
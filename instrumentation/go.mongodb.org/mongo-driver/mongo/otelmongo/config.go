@@ -16,10 +16,17 @@ package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mong
 
 import (
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
-const defaultTracerName = "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+const (
+	defaultTracerName = "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+	// defaultCommandAttributeMaxLength is the default maximum length of
+	// the db.statement attribute.
+	defaultCommandAttributeMaxLength = 1024
+)
 
 // config is used to configure the mongo tracer.
 type config struct {
@@ -27,14 +34,22 @@ type config struct {
 
 	Tracer trace.Tracer
 
+	MeterProvider metric.MeterProvider
+
+	Meter metric.Meter
+
 	CommandAttributeDisabled bool
+
+	CommandAttributeMaxLength int
 }
 
 // newConfig returns a config with all Options set.
 func newConfig(opts ...Option) config {
 	cfg := config{
-		TracerProvider:           otel.GetTracerProvider(),
-		CommandAttributeDisabled: true,
+		TracerProvider:            otel.GetTracerProvider(),
+		MeterProvider:             otel.GetMeterProvider(),
+		CommandAttributeDisabled:  true,
+		CommandAttributeMaxLength: defaultCommandAttributeMaxLength,
 	}
 	for _, opt := range opts {
 		opt.apply(&cfg)
@@ -44,6 +59,10 @@ func newConfig(opts ...Option) config {
 		defaultTracerName,
 		trace.WithInstrumentationVersion(Version()),
 	)
+	cfg.Meter = cfg.MeterProvider.Meter(
+		defaultTracerName,
+		metric.WithInstrumentationVersion(Version()),
+	)
 	return cfg
 }
 
@@ -68,11 +87,33 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global provider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithCommandAttributeDisabled specifies if the MongoDB command is added as an attribute to Spans or not.
 // This is disabled by default and the MongoDB command will not be added as an attribute
-// to Spans if this option is not provided.
+// to Spans if this option is not provided. When enabled, the command is added as the
+// db.statement attribute with all its literal values replaced by "?" so that it does
+// not record sensitive data.
 func WithCommandAttributeDisabled(disabled bool) Option {
 	return optionFunc(func(cfg *config) {
 		cfg.CommandAttributeDisabled = disabled
 	})
 }
+
+// WithCommandAttributeMaxLength specifies the maximum length in bytes of the
+// MongoDB command added as an attribute to Spans. Longer commands are
+// truncated. The maximum length is 1024 bytes by default, a length lower
+// than or equal to 0 disables the limit.
+func WithCommandAttributeMaxLength(maxLength int) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.CommandAttributeMaxLength = maxLength
+	})
+}
@@ -18,7 +18,11 @@
 // go.mongodb.org/mongo-driver/mongo.
 //
 // `NewMonitor` will return an event.CommandMonitor which is used to trace
-// requests.
+// requests and to record their duration.
+//
+// `NewPoolMonitor` will return an event.PoolMonitor which is used to record
+// the usage of the connection pools, and `NewServerMonitor` will return an
+// event.ServerMonitor which is used to record the server heartbeats.
 //
 // This code was originally based on the following:
 // - https://github.com/DataDog/dd-trace-go/tree/02f0449efa3cb382d499fadc873957385dcb2192/contrib/go.mongodb.org/mongo-driver/mongo
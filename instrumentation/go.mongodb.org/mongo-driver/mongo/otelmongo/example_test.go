@@ -28,6 +28,8 @@ func Example() {
 	// connect to MongoDB
 	opts := options.Client()
 	opts.Monitor = otelmongo.NewMonitor()
+	opts.PoolMonitor = otelmongo.NewPoolMonitor()
+	opts.ServerMonitor = otelmongo.NewServerMonitor()
 	opts.ApplyURI("mongodb://localhost:27017")
 	client, err := mongo.Connect(context.Background(), opts)
 	if err != nil {
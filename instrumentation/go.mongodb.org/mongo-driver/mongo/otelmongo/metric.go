@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Names of the instruments recorded by the monitors.
+const (
+	CommandDuration     = "db.client.operation.duration"    // Duration of commands
+	ConnectionsUsage    = "db.client.connections.usage"     // Number of connections by state
+	ConnectionsWaitTime = "db.client.connections.wait_time" // Time it took to check out a connection from the pool
+	ConnectionsTimeouts = "db.client.connections.timeouts"  // Number of connection check outs which timed out
+	ConnectionsCreated  = "db.client.connections.created"   // Number of connections created
+	ConnectionsClosed   = "db.client.connections.closed"    // Number of connections closed
+	HeartbeatDuration   = "db.mongodb.heartbeat.duration"   // Duration of successful server heartbeats
+	HeartbeatFailures   = "db.mongodb.heartbeat.failures"   // Number of failed server heartbeats
+)
+
+// Attributes of the instruments recorded by the monitors.
+const (
+	PoolNameKey         = attribute.Key("pool.name")                    // Address of the server of the pool
+	StateKey            = attribute.Key("state")                        // State of connections, "idle" or "used"
+	HeartbeatAwaitedKey = attribute.Key("db.mongodb.heartbeat.awaited") // Whether the server heartbeat was awaitable
+)
+
+type instruments struct {
+	commandDuration     metric.Float64Histogram
+	connectionsUsage    metric.Int64UpDownCounter
+	connectionsWaitTime metric.Float64Histogram
+	connectionsTimeouts metric.Int64Counter
+	connectionsCreated  metric.Int64Counter
+	connectionsClosed   metric.Int64Counter
+	heartbeatDuration   metric.Float64Histogram
+	heartbeatFailures   metric.Int64Counter
+}
+
+func newInstruments(meter metric.Meter) instruments {
+	var (
+		i   instruments
+		err error
+	)
+
+	i.commandDuration, err = meter.Float64Histogram(
+		CommandDuration,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of MongoDB commands."),
+	)
+	handleErr(err)
+
+	i.connectionsUsage, err = meter.Int64UpDownCounter(
+		ConnectionsUsage,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The number of connections that are currently in the state described by the state attribute."),
+	)
+	handleErr(err)
+
+	i.connectionsWaitTime, err = meter.Float64Histogram(
+		ConnectionsWaitTime,
+		metric.WithUnit("ms"),
+		metric.WithDescription("The time it took to check out a connection from the pool."),
+	)
+	handleErr(err)
+
+	i.connectionsTimeouts, err = meter.Int64Counter(
+		ConnectionsTimeouts,
+		metric.WithUnit("{timeout}"),
+		metric.WithDescription("The number of connection check outs from the pool which timed out."),
+	)
+	handleErr(err)
+
+	i.connectionsCreated, err = meter.Int64Counter(
+		ConnectionsCreated,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The number of connections created by the pool."),
+	)
+	handleErr(err)
+
+	i.connectionsClosed, err = meter.Int64Counter(
+		ConnectionsClosed,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The number of connections closed by the pool."),
+	)
+	handleErr(err)
+
+	i.heartbeatDuration, err = meter.Float64Histogram(
+		HeartbeatDuration,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of successful server heartbeats."),
+	)
+	handleErr(err)
+
+	i.heartbeatFailures, err = meter.Int64Counter(
+		HeartbeatFailures,
+		metric.WithUnit("{heartbeat}"),
+		metric.WithDescription("The number of failed server heartbeats."),
+	)
+	handleErr(err)
+
+	return i
+}
+
+func handleErr(err error) {
+	if err != nil {
+		otel.Handle(err)
+	}
+}
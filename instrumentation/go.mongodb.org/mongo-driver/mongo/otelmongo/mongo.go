@@ -20,9 +20,11 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 
@@ -35,16 +37,24 @@ type spanKey struct {
 	RequestID    int64
 }
 
+// startedCommand is a command waiting for its outcome.
+type startedCommand struct {
+	span trace.Span
+	// attrs are the attributes of the duration of the command.
+	attrs metric.MeasurementOption
+}
+
 type monitor struct {
 	sync.Mutex
-	spans map[spanKey]trace.Span
-	cfg   config
+	spans       map[spanKey]startedCommand
+	cfg         config
+	instruments instruments
 }
 
 func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
 	var spanName string
 
-	hostname, port := peerInfo(evt)
+	hostname, port := peerInfo(evt.ConnectionID)
 
 	attrs := []attribute.KeyValue{
 		semconv.DBSystemMongoDB,
@@ -52,15 +62,19 @@ func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
 		semconv.DBName(evt.DatabaseName),
 		semconv.NetPeerName(hostname),
 		semconv.NetPeerPort(port),
-		semconv.NetTransportTCP,
-	}
-	if !m.cfg.CommandAttributeDisabled {
-		attrs = append(attrs, semconv.DBStatement(sanitizeCommand(evt.Command)))
 	}
 	if collection, err := extractCollection(evt); err == nil && collection != "" {
 		spanName = collection + "."
 		attrs = append(attrs, semconv.DBMongoDBCollection(collection))
 	}
+	// the duration of the command is recorded with the attributes
+	// identifying the command and the server it is sent to
+	metricAttrs := metric.WithAttributes(attrs...)
+
+	attrs = append(attrs, semconv.NetTransportTCP)
+	if !m.cfg.CommandAttributeDisabled {
+		attrs = append(attrs, semconv.DBStatement(obfuscateCommand(evt.Command, m.cfg.CommandAttributeMaxLength)))
+	}
 	spanName += evt.CommandName
 	opts := []trace.SpanStartOption{
 		trace.WithSpanKind(trace.SpanKindClient),
@@ -72,7 +86,7 @@ func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
 		RequestID:    evt.RequestID,
 	}
 	m.Lock()
-	m.spans[key] = span
+	m.spans[key] = startedCommand{span: span, attrs: metricAttrs}
 	m.Unlock()
 }
 
@@ -90,7 +104,7 @@ func (m *monitor) Finished(evt *event.CommandFinishedEvent, err error) {
 		RequestID:    evt.RequestID,
 	}
 	m.Lock()
-	span, ok := m.spans[key]
+	cmd, ok := m.spans[key]
 	if ok {
 		delete(m.spans, key)
 	}
@@ -100,17 +114,15 @@ func (m *monitor) Finished(evt *event.CommandFinishedEvent, err error) {
 	}
 
 	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
+		cmd.span.SetStatus(codes.Error, err.Error())
 	}
 
-	span.End()
-}
+	cmd.span.End()
 
-// TODO sanitize values where possible, then reenable `db.statement` span attributes default.
-// TODO limit maximum size.
-func sanitizeCommand(command bson.Raw) string {
-	b, _ := bson.MarshalExtJSON(command, false, false)
-	return string(b)
+	elapsedTime := float64(evt.Duration) / float64(time.Millisecond)
+	// the context of the command may be canceled, which would drop the measurement
+	ctx := trace.ContextWithSpan(context.Background(), cmd.span)
+	m.instruments.commandDuration.Record(ctx, elapsedTime, cmd.attrs)
 }
 
 // extractCollection extracts the collection for the given mongodb command event.
@@ -136,8 +148,9 @@ func extractCollection(evt *event.CommandStartedEvent) (string, error) {
 func NewMonitor(opts ...Option) *event.CommandMonitor {
 	cfg := newConfig(opts...)
 	m := &monitor{
-		spans: make(map[spanKey]trace.Span),
-		cfg:   cfg,
+		spans:       make(map[spanKey]startedCommand),
+		cfg:         cfg,
+		instruments: newInstruments(cfg.Meter),
 	}
 	return &event.CommandMonitor{
 		Started:   m.Started,
@@ -146,8 +159,8 @@ func NewMonitor(opts ...Option) *event.CommandMonitor {
 	}
 }
 
-func peerInfo(evt *event.CommandStartedEvent) (hostname string, port int) {
-	hostname = evt.ConnectionID
+func peerInfo(connectionID string) (hostname string, port int) {
+	hostname = connectionID
 	port = 27017
 	if idx := strings.IndexByte(hostname, '['); idx >= 0 {
 		hostname = hostname[:idx]
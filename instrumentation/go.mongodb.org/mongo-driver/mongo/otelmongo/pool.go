@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// States of connections recorded with the StateKey attribute.
+const (
+	stateIdle = "idle"
+	stateUsed = "used"
+)
+
+type poolMonitor struct {
+	sync.Mutex
+	// checkOuts holds the start time of the pending connection check outs
+	// of each pool, oldest first. Pool events do not identify the check out
+	// they belong to, so check outs are assumed to complete in the order
+	// they started. The wait time of a single check out is approximate when
+	// check outs are concurrent, but the total wait time is exact.
+	checkOuts   map[string][]time.Time
+	instruments instruments
+}
+
+func (m *poolMonitor) Event(evt *event.PoolEvent) {
+	ctx := context.Background()
+	pool := metric.WithAttributes(PoolNameKey.String(evt.Address))
+	idle := metric.WithAttributes(PoolNameKey.String(evt.Address), StateKey.String(stateIdle))
+	used := metric.WithAttributes(PoolNameKey.String(evt.Address), StateKey.String(stateUsed))
+
+	switch evt.Type {
+	case event.ConnectionCreated:
+		m.instruments.connectionsCreated.Add(ctx, 1, pool)
+		m.instruments.connectionsUsage.Add(ctx, 1, idle)
+	case event.ConnectionClosed:
+		// Connections are checked in before they are closed.
+		m.instruments.connectionsClosed.Add(ctx, 1, pool)
+		m.instruments.connectionsUsage.Add(ctx, -1, idle)
+	case event.GetStarted:
+		m.Lock()
+		m.checkOuts[evt.Address] = append(m.checkOuts[evt.Address], time.Now())
+		m.Unlock()
+	case event.GetSucceeded:
+		m.checkedOut(ctx, evt.Address, pool)
+		m.instruments.connectionsUsage.Add(ctx, -1, idle)
+		m.instruments.connectionsUsage.Add(ctx, 1, used)
+	case event.GetFailed:
+		m.checkedOut(ctx, evt.Address, pool)
+		if evt.Reason == event.ReasonTimedOut {
+			m.instruments.connectionsTimeouts.Add(ctx, 1, pool)
+		}
+	case event.ConnectionReturned:
+		m.instruments.connectionsUsage.Add(ctx, -1, used)
+		m.instruments.connectionsUsage.Add(ctx, 1, idle)
+	}
+}
+
+// checkedOut records the wait time of the oldest pending check out of the
+// pool of address.
+func (m *poolMonitor) checkedOut(ctx context.Context, address string, pool metric.MeasurementOption) {
+	m.Lock()
+	checkOuts := m.checkOuts[address]
+	if len(checkOuts) == 0 {
+		m.Unlock()
+		return
+	}
+	start := checkOuts[0]
+	if len(checkOuts) == 1 {
+		delete(m.checkOuts, address)
+	} else {
+		m.checkOuts[address] = checkOuts[1:]
+	}
+	m.Unlock()
+
+	elapsedTime := float64(time.Since(start)) / float64(time.Millisecond)
+	m.instruments.connectionsWaitTime.Record(ctx, elapsedTime, pool)
+}
+
+// NewPoolMonitor creates a new mongodb event PoolMonitor recording the usage
+// of the connection pools of the client.
+func NewPoolMonitor(opts ...Option) *event.PoolMonitor {
+	cfg := newConfig(opts...)
+	m := &poolMonitor{
+		checkOuts:   make(map[string][]time.Time),
+		instruments: newInstruments(cfg.Meter),
+	}
+	return &event.PoolMonitor{
+		Event: m.Event,
+	}
+}
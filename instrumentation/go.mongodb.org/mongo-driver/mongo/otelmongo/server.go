@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+type serverMonitor struct {
+	instruments instruments
+}
+
+func (m *serverMonitor) HeartbeatSucceeded(evt *event.ServerHeartbeatSucceededEvent) {
+	elapsedTime := float64(evt.Duration) / float64(time.Millisecond)
+	m.instruments.heartbeatDuration.Record(context.Background(), elapsedTime, heartbeatAttributes(evt.ConnectionID, evt.Awaited))
+}
+
+func (m *serverMonitor) HeartbeatFailed(evt *event.ServerHeartbeatFailedEvent) {
+	m.instruments.heartbeatFailures.Add(context.Background(), 1, heartbeatAttributes(evt.ConnectionID, evt.Awaited))
+}
+
+func heartbeatAttributes(connectionID string, awaited bool) metric.MeasurementOption {
+	hostname, port := peerInfo(connectionID)
+	return metric.WithAttributes(
+		semconv.NetPeerName(hostname),
+		semconv.NetPeerPort(port),
+		HeartbeatAwaitedKey.Bool(awaited),
+	)
+}
+
+// NewServerMonitor creates a new mongodb event ServerMonitor recording the
+// heartbeats of the servers of the client. Awaited heartbeats wait for a
+// change of the server, their duration is not their latency.
+func NewServerMonitor(opts ...Option) *event.ServerMonitor {
+	cfg := newConfig(opts...)
+	m := &serverMonitor{
+		instruments: newInstruments(cfg.Meter),
+	}
+	return &event.ServerMonitor{
+		ServerHeartbeatSucceeded: m.HeartbeatSucceeded,
+		ServerHeartbeatFailed:    m.HeartbeatFailed,
+	}
+}
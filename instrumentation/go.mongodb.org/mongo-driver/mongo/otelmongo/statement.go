@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo // import "go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+
+import (
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// placeholder replaces the literal values of an obfuscated command.
+const placeholder = "?"
+
+// obfuscateCommand returns the extended JSON representation of command where
+// every literal value is replaced by a placeholder, except for the name of
+// the collection the command targets. The names of the fields and the nesting
+// of documents and arrays are kept, so the shape of the query is preserved.
+// The result is truncated to maxLength bytes if maxLength is positive.
+func obfuscateCommand(command bson.Raw, maxLength int) string {
+	elems, err := command.Elements()
+	if err != nil {
+		return ""
+	}
+
+	doc := make(bson.D, 0, len(elems))
+	for i, elem := range elems {
+		key, val := elem.Key(), elem.Value()
+		// The first element is the name of the command, its value is
+		// the collection the command targets if it is a string.
+		if i == 0 && val.Type == bson.TypeString {
+			doc = append(doc, bson.E{Key: key, Value: val.StringValue()})
+			continue
+		}
+		doc = append(doc, bson.E{Key: key, Value: obfuscateValue(val)})
+	}
+
+	b, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return ""
+	}
+	return truncate(string(b), maxLength)
+}
+
+// obfuscateValue returns val with every literal value replaced by a
+// placeholder. Arrays that only hold literal values are replaced by a single
+// placeholder, so that the result does not grow with the number of values.
+func obfuscateValue(val bson.RawValue) interface{} {
+	switch val.Type {
+	case bson.TypeEmbeddedDocument:
+		elems, err := val.Document().Elements()
+		if err != nil {
+			return placeholder
+		}
+		doc := make(bson.D, 0, len(elems))
+		for _, elem := range elems {
+			doc = append(doc, bson.E{Key: elem.Key(), Value: obfuscateValue(elem.Value())})
+		}
+		return doc
+	case bson.TypeArray:
+		values, err := val.Array().Values()
+		if err != nil {
+			return placeholder
+		}
+		if len(values) == 0 {
+			return bson.A{}
+		}
+		if !hasNested(values) {
+			return placeholder
+		}
+		arr := make(bson.A, 0, len(values))
+		for _, v := range values {
+			arr = append(arr, obfuscateValue(v))
+		}
+		return arr
+	default:
+		return placeholder
+	}
+}
+
+// hasNested returns whether values holds a document or an array.
+func hasNested(values []bson.RawValue) bool {
+	for _, v := range values {
+		if v.Type == bson.TypeEmbeddedDocument || v.Type == bson.TypeArray {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate returns s truncated to maxLength bytes, without splitting a
+// multi-byte character, if maxLength is positive.
+func truncate(s string, maxLength int) string {
+	if maxLength <= 0 || len(s) <= maxLength {
+		return s
+	}
+	for maxLength > 0 && !utf8.RuneStart(s[maxLength]) {
+		maxLength--
+	}
+	return s[:maxLength]
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestObfuscateCommand(t *testing.T) {
+	testCases := []struct {
+		name     string
+		command  bson.D
+		expected string
+	}{
+		{
+			name: "insert",
+			command: bson.D{
+				{Key: "insert", Value: "inventory"},
+				{Key: "ordered", Value: true},
+				{Key: "documents", Value: bson.A{
+					bson.D{
+						{Key: "item", Value: "canvas"},
+						{Key: "qty", Value: 100},
+						{Key: "tags", Value: bson.A{"cotton", "linen"}},
+						{Key: "size", Value: bson.D{{Key: "h", Value: 28}, {Key: "uom", Value: "cm"}}},
+					},
+				}},
+				{Key: "$db", Value: "example"},
+			},
+			expected: `{"insert":"inventory","ordered":"?","documents":[{"item":"?","qty":"?","tags":"?","size":{"h":"?","uom":"?"}}],"$db":"?"}`,
+		},
+		{
+			name: "find",
+			command: bson.D{
+				{Key: "find", Value: "inventory"},
+				{Key: "filter", Value: bson.D{
+					{Key: "qty", Value: bson.D{{Key: "$in", Value: bson.A{5, 15}}}},
+					{Key: "$or", Value: bson.A{
+						bson.D{{Key: "item", Value: "canvas"}},
+						bson.D{{Key: "status", Value: "A"}},
+					}},
+				}},
+				{Key: "sort", Value: bson.D{{Key: "item", Value: 1}}},
+				{Key: "limit", Value: 10},
+			},
+			expected: `{"find":"inventory","filter":{"qty":{"$in":"?"},"$or":[{"item":"?"},{"status":"?"}]},"sort":{"item":"?"},"limit":"?"}`,
+		},
+		{
+			name: "update",
+			command: bson.D{
+				{Key: "update", Value: "inventory"},
+				{Key: "updates", Value: bson.A{
+					bson.D{
+						{Key: "q", Value: bson.D{{Key: "item", Value: "paper"}}},
+						{Key: "u", Value: bson.D{{Key: "$set", Value: bson.D{{Key: "status", Value: "P"}}}}},
+					},
+				}},
+			},
+			expected: `{"update":"inventory","updates":[{"q":{"item":"?"},"u":{"$set":{"status":"?"}}}]}`,
+		},
+		{
+			name: "aggregate",
+			command: bson.D{
+				{Key: "aggregate", Value: "inventory"},
+				{Key: "pipeline", Value: bson.A{
+					bson.D{{Key: "$match", Value: bson.D{{Key: "status", Value: "A"}}}},
+					bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$item"}}}},
+				}},
+				{Key: "cursor", Value: bson.D{}},
+			},
+			expected: `{"aggregate":"inventory","pipeline":[{"$match":{"status":"?"}},{"$group":{"_id":"?"}}],"cursor":{}}`,
+		},
+		{
+			name: "database command",
+			command: bson.D{
+				{Key: "listCollections", Value: 1},
+				{Key: "filter", Value: bson.D{}},
+				{Key: "nameOnly", Value: true},
+			},
+			expected: `{"listCollections":"?","filter":{},"nameOnly":"?"}`,
+		},
+		{
+			name: "empty array",
+			command: bson.D{
+				{Key: "insert", Value: "inventory"},
+				{Key: "documents", Value: bson.A{}},
+			},
+			expected: `{"insert":"inventory","documents":[]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			command, err := bson.Marshal(tc.command)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, obfuscateCommand(command, 0))
+		})
+	}
+}
+
+func TestObfuscateCommandMaxLength(t *testing.T) {
+	command, err := bson.Marshal(bson.D{
+		{Key: "find", Value: "inventory"},
+		{Key: "filter", Value: bson.D{{Key: "é", Value: "canvas"}}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"find":"inventory","filter":{"é":"?"}}`, obfuscateCommand(command, 0))
+	assert.Equal(t, `{"find":"inventory"`, obfuscateCommand(command, 19))
+	// The result is not truncated in the middle of "é".
+	assert.Equal(t, `{"find":"inventory","filter":{"`, obfuscateCommand(command, 32))
+	assert.Equal(t, `{"find":"inventory","filter":{"é`, obfuscateCommand(command, 33))
+}
+
+func TestObfuscateCommandInvalid(t *testing.T) {
+	assert.Equal(t, "", obfuscateCommand(bson.Raw{0x01}, 0))
+}
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+func collectMetrics(t *testing.T, reader metric.Reader) map[string]metricdata.Metrics {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+	return metrics
+}
+
+func sumValue(t *testing.T, m metricdata.Metrics, attrs ...attribute.KeyValue) int64 {
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	require.True(t, ok, "%s is not an int64 sum", m.Name)
+	set := attribute.NewSet(attrs...)
+	for _, dp := range sum.DataPoints {
+		if dp.Attributes.Equals(&set) {
+			return dp.Value
+		}
+	}
+	t.Fatalf("%s has no data point with attributes %v", m.Name, attrs)
+	return 0
+}
+
+func TestCommandDuration(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	monitor := otelmongo.NewMonitor(otelmongo.WithMeterProvider(mp))
+
+	command, err := bson.Marshal(bson.D{{Key: "find", Value: "test-collection"}})
+	require.NoError(t, err)
+	monitor.Started(context.Background(), &event.CommandStartedEvent{
+		Command:      command,
+		DatabaseName: "test-database",
+		CommandName:  "find",
+		RequestID:    1,
+		ConnectionID: "localhost:27017[-1]",
+	})
+	monitor.Failed(context.Background(), &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{
+			Duration:     25 * time.Millisecond,
+			CommandName:  "find",
+			RequestID:    1,
+			ConnectionID: "localhost:27017[-1]",
+		},
+		Failure: "failure",
+	})
+
+	metrics := collectMetrics(t, reader)
+	duration, ok := metrics[otelmongo.CommandDuration].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 1)
+	dp := duration.DataPoints[0]
+	assert.Equal(t, uint64(1), dp.Count)
+	assert.Equal(t, float64(25), dp.Sum)
+	expected := attribute.NewSet(
+		semconv.DBSystemMongoDB,
+		semconv.DBOperation("find"),
+		semconv.DBName("test-database"),
+		semconv.NetPeerName("localhost"),
+		semconv.NetPeerPort(27017),
+		semconv.DBMongoDBCollection("test-collection"),
+	)
+	assert.Equal(t, expected, dp.Attributes)
+}
+
+func TestPoolMonitor(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	monitor := otelmongo.NewPoolMonitor(otelmongo.WithMeterProvider(mp))
+
+	const address = "localhost:27017"
+	for _, evt := range []*event.PoolEvent{
+		{Type: event.PoolCreated, Address: address},
+		// A connection is created to be checked out.
+		{Type: event.GetStarted, Address: address},
+		{Type: event.ConnectionCreated, Address: address, ConnectionID: 1},
+		{Type: event.ConnectionReady, Address: address, ConnectionID: 1},
+		{Type: event.GetSucceeded, Address: address, ConnectionID: 1},
+		// Another connection is created, checked out and returned.
+		{Type: event.GetStarted, Address: address},
+		{Type: event.ConnectionCreated, Address: address, ConnectionID: 2},
+		{Type: event.GetSucceeded, Address: address, ConnectionID: 2},
+		{Type: event.ConnectionReturned, Address: address, ConnectionID: 2},
+		// No connection is available before the timeout.
+		{Type: event.GetStarted, Address: address},
+		{Type: event.GetFailed, Address: address, Reason: event.ReasonTimedOut},
+		// The idle connection is closed.
+		{Type: event.ConnectionClosed, Address: address, ConnectionID: 2, Reason: event.ReasonIdle},
+	} {
+		monitor.Event(evt)
+	}
+
+	pool := otelmongo.PoolNameKey.String(address)
+	metrics := collectMetrics(t, reader)
+	assert.Equal(t, int64(0), sumValue(t, metrics[otelmongo.ConnectionsUsage], pool, otelmongo.StateKey.String("idle")))
+	assert.Equal(t, int64(1), sumValue(t, metrics[otelmongo.ConnectionsUsage], pool, otelmongo.StateKey.String("used")))
+	assert.Equal(t, int64(2), sumValue(t, metrics[otelmongo.ConnectionsCreated], pool))
+	assert.Equal(t, int64(1), sumValue(t, metrics[otelmongo.ConnectionsClosed], pool))
+	assert.Equal(t, int64(1), sumValue(t, metrics[otelmongo.ConnectionsTimeouts], pool))
+
+	waitTime, ok := metrics[otelmongo.ConnectionsWaitTime].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, waitTime.DataPoints, 1)
+	assert.Equal(t, uint64(3), waitTime.DataPoints[0].Count)
+}
+
+func TestServerMonitor(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	monitor := otelmongo.NewServerMonitor(otelmongo.WithMeterProvider(mp))
+
+	monitor.ServerHeartbeatSucceeded(&event.ServerHeartbeatSucceededEvent{
+		Duration:     2 * time.Millisecond,
+		ConnectionID: "localhost:27017[-1]",
+	})
+	monitor.ServerHeartbeatFailed(&event.ServerHeartbeatFailedEvent{
+		Duration:     time.Millisecond,
+		ConnectionID: "localhost:27017[-1]",
+		Awaited:      true,
+	})
+
+	metrics := collectMetrics(t, reader)
+	duration, ok := metrics[otelmongo.HeartbeatDuration].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 1)
+	assert.Equal(t, float64(2), duration.DataPoints[0].Sum)
+	expected := attribute.NewSet(
+		semconv.NetPeerName("localhost"),
+		semconv.NetPeerPort(27017),
+		otelmongo.HeartbeatAwaitedKey.Bool(false),
+	)
+	assert.Equal(t, expected, duration.DataPoints[0].Attributes)
+
+	assert.Equal(t, int64(1), sumValue(t, metrics[otelmongo.HeartbeatFailures],
+		semconv.NetPeerName("localhost"),
+		semconv.NetPeerPort(27017),
+		otelmongo.HeartbeatAwaitedKey.Bool(true),
+	))
+}
@@ -46,8 +46,47 @@ type config struct {
 	ReceivedEvent bool
 	SentEvent     bool
 
-	meter             metric.Meter
-	rpcServerDuration metric.Int64Histogram
+	RecordPanics  bool
+	RecoverPanics bool
+
+	meter     metric.Meter
+	rpcServer rpcInstruments
+	rpcClient rpcInstruments
+}
+
+// rpcInstruments are the instruments measuring the RPCs of one side (client
+// or server) of a connection.
+type rpcInstruments struct {
+	duration        metric.Int64Histogram
+	requestSize     metric.Int64Histogram
+	responseSize    metric.Int64Histogram
+	requestsPerRPC  metric.Int64Histogram
+	responsesPerRPC metric.Int64Histogram
+}
+
+// newRPCInstruments returns the rpcInstruments named with prefix (i.e.
+// "rpc.server") created by meter.
+func newRPCInstruments(meter metric.Meter, prefix string) rpcInstruments {
+	var (
+		inst rpcInstruments
+		err  error
+	)
+	if inst.duration, err = meter.Int64Histogram(prefix+".duration", metric.WithUnit("ms")); err != nil {
+		otel.Handle(err)
+	}
+	if inst.requestSize, err = meter.Int64Histogram(prefix+".request.size", metric.WithUnit("By")); err != nil {
+		otel.Handle(err)
+	}
+	if inst.responseSize, err = meter.Int64Histogram(prefix+".response.size", metric.WithUnit("By")); err != nil {
+		otel.Handle(err)
+	}
+	if inst.requestsPerRPC, err = meter.Int64Histogram(prefix+".requests_per_rpc", metric.WithUnit("{count}")); err != nil {
+		otel.Handle(err)
+	}
+	if inst.responsesPerRPC, err = meter.Int64Histogram(prefix+".responses_per_rpc", metric.WithUnit("{count}")); err != nil {
+		otel.Handle(err)
+	}
+	return inst
 }
 
 // Option applies an option value for a config.
@@ -71,10 +110,8 @@ func newConfig(opts []Option) *config {
 		metric.WithInstrumentationVersion(Version()),
 		metric.WithSchemaURL(semconv.SchemaURL),
 	)
-	var err error
-	if c.rpcServerDuration, err = c.meter.Int64Histogram("rpc.server.duration", metric.WithUnit("ms")); err != nil {
-		otel.Handle(err)
-	}
+	c.rpcServer = newRPCInstruments(c.meter, "rpc.server")
+	c.rpcClient = newRPCInstruments(c.meter, "rpc.client")
 
 	return c
 }
@@ -132,6 +169,9 @@ func (o meterProviderOption) apply(c *config) {
 
 // WithMeterProvider returns an Option to use the MeterProvider when
 // creating a Meter. If this option is not provide the global MeterProvider will be used.
+//
+// All histograms are recorded with a context holding the span of the RPC, so
+// that exemplars can link their measurements to traces.
 func WithMeterProvider(mp metric.MeterProvider) Option {
 	return meterProviderOption{mp: mp}
 }
@@ -182,3 +222,38 @@ func (o spanStartOption) apply(c *config) {
 func WithSpanOptions(opts ...trace.SpanStartOption) Option {
 	return spanStartOption{opts}
 }
+
+type panicRecordingOption struct{}
+
+func (panicRecordingOption) apply(c *config) {
+	c.RecordPanics = true
+}
+
+// WithPanicRecording makes the UnaryServerInterceptor and the
+// StreamServerInterceptor record panics of the handler before they are
+// propagated. The span is set to error and gets an exception event with the
+// stack trace of the panic, and the metrics of the RPC are recorded with the
+// INTERNAL status code.
+//
+// It has no effect on NewServerHandler: a stats.Handler is not in the call
+// stack of the handler of the RPC and cannot recover its panics.
+func WithPanicRecording() Option {
+	return panicRecordingOption{}
+}
+
+type panicRecoveryOption struct{}
+
+func (panicRecoveryOption) apply(c *config) {
+	c.RecordPanics = true
+	c.RecoverPanics = true
+}
+
+// WithPanicRecovery makes the UnaryServerInterceptor and the
+// StreamServerInterceptor record panics of the handler like
+// WithPanicRecording, but instead of propagating the panic the RPC fails with
+// the INTERNAL status code. The panic value is not sent to the client.
+//
+// Like WithPanicRecording, it has no effect on NewServerHandler.
+func WithPanicRecovery() Option {
+	return panicRecoveryOption{}
+}
@@ -21,6 +21,7 @@ import (
 	"io"
 	"net"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
@@ -100,24 +101,35 @@ func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
 
 		ctx = inject(ctx, cfg.Propagators)
 
+		o := metric.WithAttributes(attr...)
+		recordMessageSize(ctx, cfg.rpcClient.requestSize, req, o)
+
 		if cfg.SentEvent {
 			messageSent.Event(ctx, 1, req)
 		}
 
+		beforeInvoke := time.Now()
 		err := invoker(ctx, method, req, reply, cc, callOpts...)
 
 		if cfg.ReceivedEvent {
 			messageReceived.Event(ctx, 1, reply)
 		}
 
+		var responses int64
+		grpcCode := grpc_codes.OK
 		if err != nil {
 			s, _ := status.FromError(err)
+			grpcCode = s.Code()
 			span.SetStatus(codes.Error, s.Message())
 			span.SetAttributes(statusCodeAttr(s.Code()))
 		} else {
+			responses = 1
+			recordMessageSize(ctx, cfg.rpcClient.responseSize, reply, o)
 			span.SetAttributes(statusCodeAttr(grpc_codes.OK))
 		}
 
+		cfg.rpcClient.record(ctx, time.Since(beforeInvoke), 1, responses, attr, grpcCode)
+
 		return err
 	}
 }
@@ -149,13 +161,24 @@ type clientStream struct {
 
 	receivedMessageID int
 	sentMessageID     int
-}
 
-var _ = proto.Marshal
+	// The number of messages successfully sent and received. They are
+	// accessed atomically as the stream may be finished concurrently.
+	sentMessages     int64
+	receivedMessages int64
+
+	instruments  *rpcInstruments
+	metricOption metric.RecordOption
+}
 
 func (w *clientStream) RecvMsg(m interface{}) error {
 	err := w.ClientStream.RecvMsg(m)
 
+	if err == nil {
+		atomic.AddInt64(&w.receivedMessages, 1)
+		recordMessageSize(w.Context(), w.instruments.responseSize, m, w.metricOption)
+	}
+
 	if err == nil && !w.desc.ServerStreams {
 		w.sendStreamEvent(receiveEndEvent, nil)
 	} else if err == io.EOF {
@@ -176,6 +199,11 @@ func (w *clientStream) RecvMsg(m interface{}) error {
 func (w *clientStream) SendMsg(m interface{}) error {
 	err := w.ClientStream.SendMsg(m)
 
+	if err == nil {
+		atomic.AddInt64(&w.sentMessages, 1)
+		recordMessageSize(w.Context(), w.instruments.requestSize, m, w.metricOption)
+	}
+
 	w.sentMessageID++
 
 	if w.sentEvent {
@@ -209,7 +237,7 @@ func (w *clientStream) CloseSend() error {
 	return err
 }
 
-func wrapClientStream(ctx context.Context, s grpc.ClientStream, desc *grpc.StreamDesc, cfg *config) *clientStream {
+func wrapClientStream(ctx context.Context, s grpc.ClientStream, desc *grpc.StreamDesc, cfg *config, attr []attribute.KeyValue) *clientStream {
 	events := make(chan streamEvent)
 	eventsDone := make(chan struct{})
 	finished := make(chan error)
@@ -243,6 +271,8 @@ func wrapClientStream(ctx context.Context, s grpc.ClientStream, desc *grpc.Strea
 		finished:      finished,
 		receivedEvent: cfg.ReceivedEvent,
 		sentEvent:     cfg.SentEvent,
+		instruments:   &cfg.rpcClient,
+		metricOption:  metric.WithAttributes(attr...),
 	}
 }
 
@@ -294,21 +324,25 @@ func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
 
 		ctx = inject(ctx, cfg.Propagators)
 
+		beforeStream := time.Now()
 		s, err := streamer(ctx, desc, cc, method, callOpts...)
 		if err != nil {
 			grpcStatus, _ := status.FromError(err)
 			span.SetStatus(codes.Error, grpcStatus.Message())
 			span.SetAttributes(statusCodeAttr(grpcStatus.Code()))
 			span.End()
+			cfg.rpcClient.record(ctx, time.Since(beforeStream), 0, 0, attr, grpcStatus.Code())
 			return s, err
 		}
-		stream := wrapClientStream(ctx, s, desc, cfg)
+		stream := wrapClientStream(ctx, s, desc, cfg, attr)
 
 		go func() {
 			err := <-stream.finished
 
+			grpcCode := grpc_codes.OK
 			if err != nil {
 				s, _ := status.FromError(err)
+				grpcCode = s.Code()
 				span.SetStatus(codes.Error, s.Message())
 				span.SetAttributes(statusCodeAttr(s.Code()))
 			} else {
@@ -316,6 +350,11 @@ func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
 			}
 
 			span.End()
+
+			// The duration spans the whole life of the stream.
+			sent := atomic.LoadInt64(&stream.sentMessages)
+			received := atomic.LoadInt64(&stream.receivedMessages)
+			cfg.rpcClient.record(ctx, time.Since(beforeStream), sent, received, attr, grpcCode)
 		}()
 
 		return stream, nil
@@ -336,7 +375,7 @@ func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
 		req interface{},
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
-	) (interface{}, error) {
+	) (resp interface{}, err error) {
 		i := &InterceptorInfo{
 			UnaryServerInfo: info,
 			Type:            UnaryServer,
@@ -365,17 +404,30 @@ func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
 			messageReceived.Event(ctx, 1, req)
 		}
 
-		var statusCode grpc_codes.Code
+		o := metric.WithAttributes(attr...)
+		recordMessageSize(ctx, cfg.rpcServer.requestSize, req, o)
+
+		var (
+			grpcCode  grpc_codes.Code
+			responses int64
+		)
 		defer func(t time.Time) {
-			elapsedTime := time.Since(t) / time.Millisecond
-			attr = append(attr, semconv.RPCGRPCStatusCodeKey.Int64(int64(statusCode)))
-			o := metric.WithAttributes(attr...)
-			cfg.rpcServerDuration.Record(ctx, int64(elapsedTime), o)
+			cfg.rpcServer.record(ctx, time.Since(t), 1, responses, attr, grpcCode)
 		}(time.Now())
 
-		resp, err := handler(ctx, req)
+		if cfg.RecordPanics {
+			defer func() {
+				if rec := recover(); rec != nil {
+					grpcCode = grpc_codes.Internal
+					resp, err = nil, handlePanic(cfg, span, rec)
+				}
+			}()
+		}
+
+		resp, err = handler(ctx, req)
 		if err != nil {
 			s, _ := status.FromError(err)
+			grpcCode = s.Code()
 			statusCode, msg := serverStatus(s)
 			span.SetStatus(statusCode, msg)
 			span.SetAttributes(statusCodeAttr(s.Code()))
@@ -383,7 +435,9 @@ func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
 				messageSent.Event(ctx, 1, s.Proto())
 			}
 		} else {
-			statusCode = grpc_codes.OK
+			grpcCode = grpc_codes.OK
+			responses = 1
+			recordMessageSize(ctx, cfg.rpcServer.responseSize, resp, o)
 			span.SetAttributes(statusCodeAttr(grpc_codes.OK))
 			if cfg.SentEvent {
 				messageSent.Event(ctx, 1, resp)
@@ -405,6 +459,14 @@ type serverStream struct {
 
 	receivedEvent bool
 	sentEvent     bool
+
+	// The number of messages successfully sent and received.
+	sentMessages     int64
+	receivedMessages int64
+
+	// instruments is nil if the RPC is filtered.
+	instruments  *rpcInstruments
+	metricOption metric.RecordOption
 }
 
 func (w *serverStream) Context() context.Context {
@@ -415,6 +477,11 @@ func (w *serverStream) RecvMsg(m interface{}) error {
 	err := w.ServerStream.RecvMsg(m)
 
 	if err == nil {
+		atomic.AddInt64(&w.receivedMessages, 1)
+		if w.instruments != nil {
+			recordMessageSize(w.Context(), w.instruments.requestSize, m, w.metricOption)
+		}
+
 		w.receivedMessageID++
 		if w.receivedEvent {
 			messageReceived.Event(w.Context(), w.receivedMessageID, m)
@@ -427,6 +494,13 @@ func (w *serverStream) RecvMsg(m interface{}) error {
 func (w *serverStream) SendMsg(m interface{}) error {
 	err := w.ServerStream.SendMsg(m)
 
+	if err == nil {
+		atomic.AddInt64(&w.sentMessages, 1)
+		if w.instruments != nil {
+			recordMessageSize(w.Context(), w.instruments.responseSize, m, w.metricOption)
+		}
+	}
+
 	w.sentMessageID++
 	if w.sentEvent {
 		messageSent.Event(w.Context(), w.sentMessageID, m)
@@ -435,13 +509,20 @@ func (w *serverStream) SendMsg(m interface{}) error {
 	return err
 }
 
-func wrapServerStream(ctx context.Context, ss grpc.ServerStream, cfg *config) *serverStream {
-	return &serverStream{
+// wrapServerStream wraps ss to record the configured events and, if attr is
+// not nil, the message size metrics of the RPC.
+func wrapServerStream(ctx context.Context, ss grpc.ServerStream, cfg *config, attr []attribute.KeyValue) *serverStream {
+	w := &serverStream{
 		ServerStream:  ss,
 		ctx:           ctx,
 		receivedEvent: cfg.ReceivedEvent,
 		sentEvent:     cfg.SentEvent,
 	}
+	if attr != nil {
+		w.instruments = &cfg.rpcServer
+		w.metricOption = metric.WithAttributes(attr...)
+	}
+	return w
 }
 
 // StreamServerInterceptor returns a grpc.StreamServerInterceptor suitable
@@ -458,14 +539,14 @@ func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
 		ss grpc.ServerStream,
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
-	) error {
+	) (err error) {
 		ctx := ss.Context()
 		i := &InterceptorInfo{
 			StreamServerInfo: info,
 			Type:             StreamServer,
 		}
 		if cfg.Filter != nil && !cfg.Filter(i) {
-			return handler(srv, wrapServerStream(ctx, ss, cfg))
+			return handler(srv, wrapServerStream(ctx, ss, cfg, nil))
 		}
 
 		ctx = extract(ctx, cfg.Propagators)
@@ -484,9 +565,27 @@ func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
 		)
 		defer span.End()
 
-		err := handler(srv, wrapServerStream(ctx, ss, cfg))
+		stream := wrapServerStream(ctx, ss, cfg, attr)
+		grpcCode := grpc_codes.OK
+		defer func(t time.Time) {
+			received := atomic.LoadInt64(&stream.receivedMessages)
+			sent := atomic.LoadInt64(&stream.sentMessages)
+			cfg.rpcServer.record(ctx, time.Since(t), received, sent, attr, grpcCode)
+		}(time.Now())
+
+		if cfg.RecordPanics {
+			defer func() {
+				if rec := recover(); rec != nil {
+					grpcCode = grpc_codes.Internal
+					err = handlePanic(cfg, span, rec)
+				}
+			}()
+		}
+
+		err = handler(srv, stream)
 		if err != nil {
 			s, _ := status.FromError(err)
+			grpcCode = s.Code()
 			statusCode, msg := serverStatus(s)
 			span.SetStatus(statusCode, msg)
 			span.SetAttributes(statusCodeAttr(s.Code()))
@@ -498,6 +597,49 @@ func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
 	}
 }
 
+// handlePanic records rec, the value recovered from a panic of the handler of
+// the RPC of span. It returns the error failing the RPC if the panics are
+// recovered, and propagates the panic otherwise.
+func handlePanic(cfg *config, span trace.Span, rec interface{}) error {
+	span.SetAttributes(statusCodeAttr(grpc_codes.Internal))
+	recordPanic(span, rec)
+
+	if cfg.RecoverPanics {
+		// The panic value may hold internal state, so it is only recorded on
+		// the span.
+		return status.Error(grpc_codes.Internal, "internal error")
+	}
+
+	// End the span before the panic is propagated so the SDK does not record
+	// it a second time.
+	span.End()
+	panic(rec)
+}
+
+// recordMessageSize records the size of m with h if m is a protobuf message.
+func recordMessageSize(ctx context.Context, h metric.Int64Histogram, m interface{}, opts ...metric.RecordOption) {
+	if p, ok := m.(proto.Message); ok {
+		// A stream's context is canceled as soon as its last message is
+		// received.
+		h.Record(withoutCancel(ctx), int64(proto.Size(p)), opts...)
+	}
+}
+
+// record records the duration and the number of request and response
+// messages of a completed RPC with the attributes of the RPC and its status
+// code.
+func (inst *rpcInstruments) record(ctx context.Context, elapsed time.Duration, requests, responses int64, attr []attribute.KeyValue, code grpc_codes.Code) {
+	attr = append(attr[:len(attr):len(attr)], semconv.RPCGRPCStatusCodeKey.Int64(int64(code)))
+	o := metric.WithAttributes(attr...)
+
+	// The context of the RPC may already be canceled when it completes, which
+	// would otherwise drop the measurements.
+	ctx = withoutCancel(ctx)
+	inst.duration.Record(ctx, elapsed.Milliseconds(), o)
+	inst.requestsPerRPC.Record(ctx, requests, o)
+	inst.responsesPerRPC.Record(ctx, responses, o)
+}
+
 // spanInfo returns a span name and all appropriate attributes from the gRPC
 // method and peer address.
 func spanInfo(fullMethod, peerAddress string) (string, []attribute.KeyValue) {
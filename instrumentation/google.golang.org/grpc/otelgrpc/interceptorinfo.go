@@ -34,12 +34,19 @@ const (
 	UnaryServer
 	// StreamServer is the type for grpc.StreamServer interceptor.
 	StreamServer
+	// ClientStatsHandler is the type for the stats.Handler returned by
+	// NewClientHandler.
+	ClientStatsHandler
+	// ServerStatsHandler is the type for the stats.Handler returned by
+	// NewServerHandler.
+	ServerStatsHandler
 )
 
 // InterceptorInfo is the union of some arguments to four types of
 // gRPC interceptors.
 type InterceptorInfo struct {
-	// Method is method name registered to UnaryClient and StreamClient
+	// Method is method name registered to UnaryClient, StreamClient,
+	// ClientStatsHandler and ServerStatsHandler
 	Method string
 	// UnaryServerInfo is the metadata for UnaryServer
 	UnaryServerInfo *grpc.UnaryServerInfo
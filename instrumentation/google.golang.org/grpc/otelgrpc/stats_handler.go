@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelgrpc // import "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+
+import (
+	"context"
+	"sync/atomic"
+
+	grpc_codes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type gRPCContextKey struct{}
+
+// gRPCContext is the per-RPC state of a stats.Handler. It is stored in the
+// context returned from TagRPC and only exists for RPCs that are traced.
+type gRPCContext struct {
+	span trace.Span
+
+	// attrs are the attributes of the RPC that are added to its metrics.
+	attrs []attribute.KeyValue
+
+	receivedMessageID int64
+	sentMessageID     int64
+}
+
+// NewServerHandler returns a stats.Handler that traces the RPCs of a gRPC
+// server. It is suitable for use in a grpc.NewServer call using the
+// grpc.StatsHandler server option.
+//
+// Unlike the server interceptors, a single handler instruments both unary and
+// streaming RPCs, and the message events it records contain the compressed
+// and uncompressed size of every message. It cannot record nor recover the
+// panics of the handlers of the RPCs.
+func NewServerHandler(opts ...Option) stats.Handler {
+	h := &serverHandler{config: newConfig(opts)}
+	h.tracer = h.TracerProvider.Tracer(
+		instrumentationName,
+		trace.WithInstrumentationVersion(Version()),
+	)
+	return h
+}
+
+type serverHandler struct {
+	*config
+
+	tracer trace.Tracer
+}
+
+// TagConn can attach some information to the given context.
+func (h *serverHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn processes the Conn stats.
+func (h *serverHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+// TagRPC starts the span of the RPC described by info if it is not filtered.
+func (h *serverHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	i := &InterceptorInfo{
+		Method: info.FullMethodName,
+		Type:   ServerStatsHandler,
+	}
+	if h.Filter != nil && !h.Filter(i) {
+		return ctx
+	}
+
+	ctx = extract(ctx, h.Propagators)
+	name, attr := spanInfo(info.FullMethodName, peerFromCtx(ctx))
+
+	startOpts := append([]trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attr...)},
+		h.SpanStartOptions...,
+	)
+
+	ctx, span := h.tracer.Start(
+		trace.ContextWithRemoteSpanContext(ctx, trace.SpanContextFromContext(ctx)),
+		name,
+		startOpts...,
+	)
+
+	gctx := &gRPCContext{span: span, attrs: attr}
+	return context.WithValue(ctx, gRPCContextKey{}, gctx)
+}
+
+// HandleRPC processes the RPC stats.
+func (h *serverHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	gctx, ok := ctx.Value(gRPCContextKey{}).(*gRPCContext)
+	if !ok {
+		// The RPC is filtered.
+		return
+	}
+
+	if end, ok := rs.(*stats.End); ok {
+		code := grpc_codes.OK
+		if end.Error != nil {
+			s, _ := status.FromError(end.Error)
+			code = s.Code()
+			statusCode, msg := serverStatus(s)
+			gctx.span.SetStatus(statusCode, msg)
+		}
+		gctx.span.SetAttributes(statusCodeAttr(code))
+		gctx.span.End()
+
+		received := atomic.LoadInt64(&gctx.receivedMessageID)
+		sent := atomic.LoadInt64(&gctx.sentMessageID)
+		h.rpcServer.record(ctx, end.EndTime.Sub(end.BeginTime), received, sent, gctx.attrs, code)
+		return
+	}
+
+	h.handleMessage(ctx, gctx, rs, h.rpcServer.requestSize, h.rpcServer.responseSize)
+}
+
+// NewClientHandler returns a stats.Handler that traces the RPCs of a gRPC
+// client. It is suitable for use in a grpc.Dial call using the
+// grpc.WithStatsHandler dial option.
+//
+// Unlike the client interceptors, a single handler instruments both unary and
+// streaming RPCs, every retry attempt of an RPC is traced with its own span,
+// and the message events it records contain the compressed and uncompressed
+// size of every message.
+func NewClientHandler(opts ...Option) stats.Handler {
+	h := &clientHandler{config: newConfig(opts)}
+	h.tracer = h.TracerProvider.Tracer(
+		instrumentationName,
+		trace.WithInstrumentationVersion(Version()),
+	)
+	return h
+}
+
+type clientHandler struct {
+	*config
+
+	tracer trace.Tracer
+}
+
+// TagConn can attach some information to the given context.
+func (h *clientHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn processes the Conn stats.
+func (h *clientHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+// TagRPC starts the span of the RPC described by info, if it is not
+// filtered, and injects it into the outgoing metadata.
+func (h *clientHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	i := &InterceptorInfo{
+		Method: info.FullMethodName,
+		Type:   ClientStatsHandler,
+	}
+	if h.Filter != nil && !h.Filter(i) {
+		return ctx
+	}
+
+	// The peer is not known yet, it is added once the headers are sent.
+	name, attr := spanInfo(info.FullMethodName, "")
+
+	startOpts := append([]trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attr...)},
+		h.SpanStartOptions...,
+	)
+
+	ctx, span := h.tracer.Start(
+		ctx,
+		name,
+		startOpts...,
+	)
+
+	gctx := &gRPCContext{span: span, attrs: attr}
+	return inject(context.WithValue(ctx, gRPCContextKey{}, gctx), h.Propagators)
+}
+
+// HandleRPC processes the RPC stats.
+func (h *clientHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	gctx, ok := ctx.Value(gRPCContextKey{}).(*gRPCContext)
+	if !ok {
+		// The RPC is filtered.
+		return
+	}
+
+	switch rs := rs.(type) {
+	case *stats.OutHeader:
+		if rs.RemoteAddr != nil {
+			gctx.span.SetAttributes(peerAttr(rs.RemoteAddr.String())...)
+		}
+	case *stats.End:
+		code := grpc_codes.OK
+		if rs.Error != nil {
+			s, _ := status.FromError(rs.Error)
+			code = s.Code()
+			gctx.span.SetStatus(codes.Error, s.Message())
+		}
+		gctx.span.SetAttributes(statusCodeAttr(code))
+		gctx.span.End()
+
+		sent := atomic.LoadInt64(&gctx.sentMessageID)
+		received := atomic.LoadInt64(&gctx.receivedMessageID)
+		h.rpcClient.record(ctx, rs.EndTime.Sub(rs.BeginTime), sent, received, gctx.attrs, code)
+	default:
+		h.handleMessage(ctx, gctx, rs, h.rpcClient.responseSize, h.rpcClient.requestSize)
+	}
+}
+
+// handleMessage records the size of the payload in rs with received or sent
+// and adds a message event to the span of gctx for it, if message events are
+// configured.
+func (c *config) handleMessage(ctx context.Context, gctx *gRPCContext, rs stats.RPCStats, received, sent metric.Int64Histogram) {
+	ctx, o := withoutCancel(ctx), metric.WithAttributes(gctx.attrs...)
+	switch rs := rs.(type) {
+	case *stats.InPayload:
+		id := atomic.AddInt64(&gctx.receivedMessageID, 1)
+		received.Record(ctx, int64(rs.Length), o)
+		if c.ReceivedEvent {
+			payloadEvent(gctx.span, messageReceived, id, rs.CompressedLength, rs.Length)
+		}
+	case *stats.OutPayload:
+		id := atomic.AddInt64(&gctx.sentMessageID, 1)
+		sent.Record(ctx, int64(rs.Length), o)
+		if c.SentEvent {
+			payloadEvent(gctx.span, messageSent, id, rs.CompressedLength, rs.Length)
+		}
+	}
+}
+
+// payloadEvent adds a message event of type m with its wire-level sizes to
+// span.
+func payloadEvent(span trace.Span, m messageType, id int64, compressed, uncompressed int) {
+	if !span.IsRecording() {
+		return
+	}
+	span.AddEvent("message", trace.WithAttributes(
+		attribute.KeyValue(m),
+		RPCMessageIDKey.Int64(id),
+		RPCMessageCompressedSizeKey.Int(compressed),
+		RPCMessageUncompressedSizeKey.Int(uncompressed),
+	))
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestHistogramsRecordedWithSpanContext(t *testing.T) {
+	instrumentations := []struct {
+		name string
+		opts func(client, server []otelgrpc.Option) ([]grpc.DialOption, []grpc.ServerOption)
+	}{
+		{
+			name: "interceptors",
+			opts: func(client, server []otelgrpc.Option) ([]grpc.DialOption, []grpc.ServerOption) {
+				return []grpc.DialOption{
+					grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor(client...)),
+					grpc.WithChainStreamInterceptor(otelgrpc.StreamClientInterceptor(client...)),
+				}, []grpc.ServerOption{
+					grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor(server...)),
+					grpc.StreamInterceptor(otelgrpc.StreamServerInterceptor(server...)),
+				}
+			},
+		},
+		{
+			name: "stats handlers",
+			opts: func(client, server []otelgrpc.Option) ([]grpc.DialOption, []grpc.ServerOption) {
+				return []grpc.DialOption{
+					grpc.WithStatsHandler(otelgrpc.NewClientHandler(client...)),
+				}, []grpc.ServerOption{
+					grpc.StatsHandler(otelgrpc.NewServerHandler(server...)),
+				}
+			},
+		},
+	}
+	unsampledParent := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID: oteltrace.TraceID{0x01},
+		SpanID:  oteltrace.SpanID{0x01},
+	})
+	samplers := []struct {
+		name    string
+		sampler trace.Sampler
+		parent  oteltrace.SpanContext
+		sampled bool
+	}{
+		{name: "sampled", sampler: trace.AlwaysSample(), sampled: true},
+		{name: "unsampled", sampler: trace.NeverSample()},
+		{name: "unsampled parent", sampler: trace.ParentBased(trace.AlwaysSample()), parent: unsampledParent},
+	}
+
+	for _, inst := range instrumentations {
+		for _, s := range samplers {
+			t.Run(inst.name+"/"+s.name, func(t *testing.T) {
+				reader := metric.NewManualReader()
+				mp := newSpanContextMeterProvider(metric.NewMeterProvider(metric.WithReader(reader)))
+				clientSR := tracetest.NewSpanRecorder()
+				clientTP := trace.NewTracerProvider(trace.WithSampler(s.sampler), trace.WithSpanProcessor(clientSR))
+				serverSR := tracetest.NewSpanRecorder()
+				serverTP := trace.NewTracerProvider(trace.WithSampler(s.sampler), trace.WithSpanProcessor(serverSR))
+
+				cOpts, sOpts := inst.opts(
+					[]otelgrpc.Option{otelgrpc.WithTracerProvider(clientTP), otelgrpc.WithMeterProvider(mp), otelgrpc.WithPropagators(propagation.TraceContext{})},
+					[]otelgrpc.Option{otelgrpc.WithTracerProvider(serverTP), otelgrpc.WithMeterProvider(mp), otelgrpc.WithPropagators(propagation.TraceContext{})},
+				)
+				// The RPCs are started with the parent of the case, if any,
+				// before they reach the instrumentation.
+				withParent := func(ctx context.Context) context.Context {
+					if s.parent.IsValid() {
+						ctx = oteltrace.ContextWithSpanContext(ctx, s.parent)
+					}
+					return ctx
+				}
+				cOpts = append([]grpc.DialOption{
+					grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+						return invoker(withParent(ctx), method, req, reply, cc, opts...)
+					}),
+					grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+						return streamer(withParent(ctx), desc, cc, method, opts...)
+					}),
+				}, cOpts...)
+				require.NoError(t, doCalls(cOpts, sOpts))
+
+				spanIDs := map[string]map[oteltrace.SpanID]bool{"rpc.client": {}, "rpc.server": {}}
+				for _, span := range clientSR.Ended() {
+					spanIDs["rpc.client"][span.SpanContext().SpanID()] = true
+				}
+				for _, span := range serverSR.Ended() {
+					spanIDs["rpc.server"][span.SpanContext().SpanID()] = true
+				}
+
+				recorded := mp.SpanContexts()
+				require.NotEmpty(t, recorded)
+				for name, scs := range recorded {
+					// The name starts with either "rpc.client" or "rpc.server".
+					side := strings.Join(strings.SplitN(name, ".", 3)[:2], ".")
+					for _, sc := range scs {
+						if !assert.True(t, sc.IsValid(), "%s recorded without a span context", name) {
+							continue
+						}
+						assert.Equal(t, s.sampled, sc.IsSampled(), name)
+						if s.parent.IsValid() {
+							assert.Equal(t, s.parent.TraceID(), sc.TraceID(), "%s recorded with a span context of another trace", name)
+						}
+						if sc.IsSampled() {
+							assert.True(t, spanIDs[side][sc.SpanID()], "%s recorded with a span context of another span", name)
+						}
+					}
+				}
+
+				// The measurements still reach the reader of the metric SDK.
+				rm := metricdata.ResourceMetrics{}
+				require.NoError(t, reader.Collect(context.Background(), &rm))
+				require.Len(t, rm.ScopeMetrics, 1)
+				for _, m := range rm.ScopeMetrics[0].Metrics {
+					assert.Contains(t, recorded, m.Name)
+				}
+			})
+		}
+	}
+}
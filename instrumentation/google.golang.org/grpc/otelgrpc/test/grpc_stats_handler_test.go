@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestStatsHandler(t *testing.T) {
+	clientSR := tracetest.NewSpanRecorder()
+	clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+	clientMetricReader := metric.NewManualReader()
+	clientMP := metric.NewMeterProvider(metric.WithReader(clientMetricReader))
+
+	serverSR := tracetest.NewSpanRecorder()
+	serverTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverSR))
+	serverMetricReader := metric.NewManualReader()
+	serverMP := metric.NewMeterProvider(metric.WithReader(serverMetricReader))
+
+	assert.NoError(t, doCalls(
+		[]grpc.DialOption{
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+				otelgrpc.WithTracerProvider(clientTP),
+				otelgrpc.WithPropagators(propagation.TraceContext{}),
+				otelgrpc.WithMeterProvider(clientMP),
+				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
+			)),
+		},
+		[]grpc.ServerOption{
+			grpc.StatsHandler(otelgrpc.NewServerHandler(
+				otelgrpc.WithTracerProvider(serverTP),
+				otelgrpc.WithPropagators(propagation.TraceContext{}),
+				otelgrpc.WithMeterProvider(serverMP),
+				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
+			)),
+		},
+	))
+
+	wantMessages := map[string][2]int64{}
+	var methods []string
+	for method, n := range messageCounts {
+		wantMessages["grpc.testing.TestService/"+method] = n
+		methods = append(methods, method)
+	}
+
+	t.Run("ClientSpans", func(t *testing.T) {
+		spans := clientSR.Ended()
+		require.Len(t, spans, len(wantMessages))
+		for _, span := range spans {
+			msgs, ok := wantMessages[span.Name()]
+			require.True(t, ok, "unexpected span %q", span.Name())
+			assert.Equal(t, oteltrace.SpanKindClient, span.SpanKind())
+			assert.Contains(t, span.Attributes(), otelgrpc.RPCSystemGRPC)
+			assert.Contains(t, span.Attributes(), otelgrpc.GRPCStatusCodeKey.Int64(int64(codes.OK)))
+			checkPayloadEvents(t, span.Events(), msgs[0], msgs[1])
+		}
+	})
+
+	t.Run("ServerSpans", func(t *testing.T) {
+		spans := serverSR.Ended()
+		require.Len(t, spans, len(wantMessages))
+		for _, span := range spans {
+			msgs, ok := wantMessages[span.Name()]
+			require.True(t, ok, "unexpected span %q", span.Name())
+			assert.Equal(t, oteltrace.SpanKindServer, span.SpanKind())
+			assert.Contains(t, span.Attributes(), otelgrpc.RPCSystemGRPC)
+			assert.Contains(t, span.Attributes(), otelgrpc.GRPCStatusCodeKey.Int64(int64(codes.OK)))
+			// The server receives what the client sends.
+			checkPayloadEvents(t, span.Events(), msgs[1], msgs[0])
+		}
+	})
+
+	t.Run("Propagation", func(t *testing.T) {
+		clientSpans := map[oteltrace.SpanID]trace.ReadOnlySpan{}
+		for _, span := range clientSR.Ended() {
+			clientSpans[span.SpanContext().SpanID()] = span
+		}
+		for _, span := range serverSR.Ended() {
+			parent, ok := clientSpans[span.Parent().SpanID()]
+			if assert.True(t, ok, "server span %q is not parented to a client span", span.Name()) {
+				assert.Equal(t, parent.Name(), span.Name())
+			}
+		}
+	})
+
+	t.Run("ClientMetrics", func(t *testing.T) {
+		checkRecords(t, clientMetricReader, "rpc.client", methods...)
+	})
+
+	t.Run("ServerMetrics", func(t *testing.T) {
+		checkRecords(t, serverMetricReader, "rpc.server", methods...)
+	})
+}
+
+func checkPayloadEvents(t *testing.T, events []trace.Event, sent, received int64) {
+	var gotSent, gotReceived int64
+	for _, e := range events {
+		assert.Equal(t, "message", e.Name)
+		attrs := attribute.NewSet(e.Attributes...)
+		for _, k := range []attribute.Key{
+			otelgrpc.RPCMessageIDKey,
+			otelgrpc.RPCMessageCompressedSizeKey,
+			otelgrpc.RPCMessageUncompressedSizeKey,
+		} {
+			assert.True(t, attrs.HasValue(k), "missing %s attribute", k)
+		}
+		if attrs.HasValue(otelgrpc.RPCMessageTypeKey) {
+			v, _ := attrs.Value(otelgrpc.RPCMessageTypeKey)
+			switch v.AsString() {
+			case "SENT":
+				gotSent++
+			case "RECEIVED":
+				gotReceived++
+			}
+		}
+	}
+	assert.Equal(t, sent, gotSent, "sent messages")
+	assert.Equal(t, received, gotReceived, "received messages")
+}
+
+func TestStatsHandlerFilter(t *testing.T) {
+	clientSR := tracetest.NewSpanRecorder()
+	clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+
+	serverSR := tracetest.NewSpanRecorder()
+	serverTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverSR))
+
+	filter := filters.Not(filters.MethodName("EmptyCall"))
+	assert.NoError(t, doCalls(
+		[]grpc.DialOption{
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+				otelgrpc.WithTracerProvider(clientTP),
+				otelgrpc.WithInterceptorFilter(filter),
+			)),
+		},
+		[]grpc.ServerOption{
+			grpc.StatsHandler(otelgrpc.NewServerHandler(
+				otelgrpc.WithTracerProvider(serverTP),
+				otelgrpc.WithInterceptorFilter(filter),
+			)),
+		},
+	))
+
+	for _, spans := range [][]trace.ReadOnlySpan{clientSR.Ended(), serverSR.Ended()} {
+		assert.Len(t, spans, 4)
+		for _, span := range spans {
+			assert.NotContains(t, span.Attributes(), semconv.RPCMethod("EmptyCall"))
+		}
+	}
+}
@@ -17,6 +17,7 @@ package test
 import (
 	"context"
 	"net"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -84,10 +85,14 @@ func TestInterceptors(t *testing.T) {
 	clientStreamSR := tracetest.NewSpanRecorder()
 	clientStreamTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientStreamSR))
 
+	clientMetricReader := metric.NewManualReader()
+	clientMP := metric.NewMeterProvider(metric.WithReader(clientMetricReader))
+
 	serverUnarySR := tracetest.NewSpanRecorder()
 	serverUnaryTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverUnarySR))
-	serverUnaryMetricReader := metric.NewManualReader()
-	serverUnaryMP := metric.NewMeterProvider(metric.WithReader(serverUnaryMetricReader))
+
+	serverMetricReader := metric.NewManualReader()
+	serverMP := metric.NewMeterProvider(metric.WithReader(serverMetricReader))
 
 	serverStreamSR := tracetest.NewSpanRecorder()
 	serverStreamTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverStreamSR))
@@ -96,21 +101,24 @@ func TestInterceptors(t *testing.T) {
 		[]grpc.DialOption{
 			grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor(
 				otelgrpc.WithTracerProvider(clientUnaryTP),
+				otelgrpc.WithMeterProvider(clientMP),
 				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
 			)),
 			grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor(
 				otelgrpc.WithTracerProvider(clientStreamTP),
+				otelgrpc.WithMeterProvider(clientMP),
 				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
 			)),
 		},
 		[]grpc.ServerOption{
 			grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor(
 				otelgrpc.WithTracerProvider(serverUnaryTP),
-				otelgrpc.WithMeterProvider(serverUnaryMP),
+				otelgrpc.WithMeterProvider(serverMP),
 				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
 			)),
 			grpc.StreamInterceptor(otelgrpc.StreamServerInterceptor(
 				otelgrpc.WithTracerProvider(serverStreamTP),
+				otelgrpc.WithMeterProvider(serverMP),
 				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
 			)),
 		},
@@ -126,12 +134,21 @@ func TestInterceptors(t *testing.T) {
 
 	t.Run("UnaryServerSpans", func(t *testing.T) {
 		checkUnaryServerSpans(t, serverUnarySR.Ended())
-		checkUnaryServerRecords(t, serverUnaryMetricReader)
 	})
 
 	t.Run("StreamServerSpans", func(t *testing.T) {
 		checkStreamServerSpans(t, serverStreamSR.Ended())
 	})
+
+	methods := []string{"EmptyCall", "UnaryCall", "StreamingInputCall", "StreamingOutputCall", "FullDuplexCall"}
+
+	t.Run("ClientMetrics", func(t *testing.T) {
+		checkRecords(t, clientMetricReader, "rpc.client", methods...)
+	})
+
+	t.Run("ServerMetrics", func(t *testing.T) {
+		checkRecords(t, serverMetricReader, "rpc.server", methods...)
+	})
 }
 
 func checkUnaryClientSpans(t *testing.T, spans []trace.ReadOnlySpan) {
@@ -598,25 +615,72 @@ func assertEvents(t *testing.T, expected, actual []trace.Event) bool {
 	return !failed
 }
 
-func checkUnaryServerRecords(t *testing.T, reader metric.Reader) {
+// messageCounts are the number of messages sent and received by the client
+// for each of the RPCs made by doCalls.
+var messageCounts = map[string][2]int64{
+	"EmptyCall":           {1, 1},
+	"UnaryCall":           {1, 1},
+	"StreamingInputCall":  {4, 1},
+	"StreamingOutputCall": {1, 4},
+	"FullDuplexCall":      {4, 4},
+}
+
+// checkRecords checks the RPC metrics with the prefix (i.e. "rpc.server")
+// collected by reader were recorded for exactly the methods.
+func checkRecords(t *testing.T, reader metric.Reader, prefix string, methods ...string) {
 	rm := metricdata.ResourceMetrics{}
 	err := reader.Collect(context.Background(), &rm)
 	assert.NoError(t, err)
 	require.Len(t, rm.ScopeMetrics, 1)
-	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
-	require.IsType(t, rm.ScopeMetrics[0].Metrics[0].Data, metricdata.Histogram[int64]{})
-	data := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[int64])
-
-	for _, dpt := range data.DataPoints {
-		attr := dpt.Attributes.ToSlice()
-		method := getRPCMethod(attr)
-		assert.NotEmpty(t, method)
-		assert.ElementsMatch(t, []attribute.KeyValue{
-			semconv.RPCMethod(method),
-			semconv.RPCService("grpc.testing.TestService"),
-			otelgrpc.RPCSystemGRPC,
-			otelgrpc.GRPCStatusCodeKey.Int64(int64(codes.OK)),
-		}, attr)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 5)
+
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		require.IsType(t, m.Data, metricdata.Histogram[int64]{})
+		data := m.Data.(metricdata.Histogram[int64])
+
+		got := map[string]metricdata.HistogramDataPoint[int64]{}
+		for _, dpt := range data.DataPoints {
+			attr := dpt.Attributes.ToSlice()
+			method := getRPCMethod(attr)
+			assert.NotEmpty(t, method)
+			got[method] = dpt
+
+			want := []attribute.KeyValue{
+				semconv.RPCMethod(method),
+				semconv.RPCService("grpc.testing.TestService"),
+				otelgrpc.RPCSystemGRPC,
+			}
+			if !strings.HasSuffix(m.Name, ".size") {
+				// The status is only known once the RPC completes.
+				want = append(want, otelgrpc.GRPCStatusCodeKey.Int64(int64(codes.OK)))
+			}
+			assert.ElementsMatch(t, want, attr, m.Name)
+		}
+		require.Len(t, got, len(methods), m.Name)
+
+		for _, method := range methods {
+			require.Contains(t, got, method, m.Name)
+			dpt := got[method]
+
+			requests, responses := messageCounts[method][0], messageCounts[method][1]
+			switch m.Name {
+			case prefix + ".duration":
+				assert.Equal(t, "ms", m.Unit)
+				assert.Equal(t, uint64(1), dpt.Count, "%s %s", m.Name, method)
+			case prefix + ".requests_per_rpc":
+				assert.Equal(t, requests, dpt.Sum, "%s %s", m.Name, method)
+			case prefix + ".responses_per_rpc":
+				assert.Equal(t, responses, dpt.Sum, "%s %s", m.Name, method)
+			case prefix + ".request.size":
+				assert.Equal(t, "By", m.Unit)
+				assert.Equal(t, uint64(requests), dpt.Count, "%s %s", m.Name, method)
+			case prefix + ".response.size":
+				assert.Equal(t, "By", m.Unit)
+				assert.Equal(t, uint64(responses), dpt.Count, "%s %s", m.Name, method)
+			default:
+				assert.Fail(t, "unexpected metric", m.Name)
+			}
+		}
 	}
 }
 
@@ -26,6 +26,8 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
@@ -958,6 +960,85 @@ func TestUnaryServerInterceptorEvents(t *testing.T) {
 	}
 }
 
+func TestUnaryServerInterceptorPanic(t *testing.T) {
+	testCases := []struct {
+		name      string
+		opt       otelgrpc.Option
+		wantPanic bool
+	}{
+		{
+			name:      "recording",
+			opt:       otelgrpc.WithPanicRecording(),
+			wantPanic: true,
+		},
+		{
+			name: "recovery",
+			opt:  otelgrpc.WithPanicRecovery(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+			reader := metric.NewManualReader()
+			mp := metric.NewMeterProvider(metric.WithReader(reader))
+			usi := otelgrpc.UnaryServerInterceptor(
+				otelgrpc.WithTracerProvider(tp),
+				otelgrpc.WithMeterProvider(mp),
+				tc.opt,
+			)
+			handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+				panic("handler panic")
+			}
+
+			call := func() {
+				_, err := usi(context.Background(), &grpc_testing.SimpleRequest{}, &grpc.UnaryServerInfo{FullMethod: "Panic"}, handler)
+				assert.Equal(t, grpc_codes.Internal, status.Code(err))
+				assert.NotContains(t, status.Convert(err).Message(), "handler panic", "panic value should not be sent to the client")
+			}
+			if tc.wantPanic {
+				assert.PanicsWithValue(t, "handler panic", call)
+			} else {
+				assert.NotPanics(t, call)
+			}
+
+			assertPanicRecorded(t, sr, reader, "TestUnaryServerInterceptorPanic")
+		})
+	}
+}
+
+// assertPanicRecorded asserts that the panic of the handler of the RPC named
+// Panic was recorded in its span and its metrics, with the stack trace of the
+// test function fn.
+func assertPanicRecorded(t *testing.T, sr *tracetest.SpanRecorder, reader metric.Reader, fn string) {
+	span, ok := getSpanFromRecorder(sr, "Panic")
+	require.True(t, ok, "missing span Panic")
+	assertServerSpan(t, codes.Error, "handler panic", grpc_codes.Internal, span)
+	require.Len(t, span.Events(), 1)
+	event := span.Events()[0]
+	assert.Equal(t, semconv.ExceptionEventName, event.Name)
+	assert.Contains(t, event.Attributes, semconv.ExceptionType("string"))
+	assert.Contains(t, event.Attributes, semconv.ExceptionMessage("handler panic"))
+	attrs := attribute.NewSet(event.Attributes...)
+	stack, _ := attrs.Value(semconv.ExceptionStacktraceKey)
+	assert.Contains(t, stack.AsString(), fn)
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "rpc.server.duration" {
+			continue
+		}
+		hist, ok := m.Data.(metricdata.Histogram[int64])
+		require.True(t, ok, "unexpected data type %T", m.Data)
+		require.Len(t, hist.DataPoints, 1)
+		v, _ := hist.DataPoints[0].Attributes.Value(semconv.RPCGRPCStatusCodeKey)
+		assert.Equal(t, int64(grpc_codes.Internal), v.AsInt64())
+	}
+}
+
 type mockServerStream struct {
 	grpc.ServerStream
 }
@@ -998,6 +1079,54 @@ func TestStreamServerInterceptor(t *testing.T) {
 	}
 }
 
+func TestStreamServerInterceptorPanic(t *testing.T) {
+	testCases := []struct {
+		name      string
+		opt       otelgrpc.Option
+		wantPanic bool
+	}{
+		{
+			name:      "recording",
+			opt:       otelgrpc.WithPanicRecording(),
+			wantPanic: true,
+		},
+		{
+			name: "recovery",
+			opt:  otelgrpc.WithPanicRecovery(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+			reader := metric.NewManualReader()
+			mp := metric.NewMeterProvider(metric.WithReader(reader))
+			ssi := otelgrpc.StreamServerInterceptor(
+				otelgrpc.WithTracerProvider(tp),
+				otelgrpc.WithMeterProvider(mp),
+				tc.opt,
+			)
+			handler := func(_ interface{}, _ grpc.ServerStream) error {
+				panic("handler panic")
+			}
+
+			call := func() {
+				err := ssi(&grpc_testing.SimpleRequest{}, &mockServerStream{}, &grpc.StreamServerInfo{FullMethod: "Panic"}, handler)
+				assert.Equal(t, grpc_codes.Internal, status.Code(err))
+				assert.NotContains(t, status.Convert(err).Message(), "handler panic", "panic value should not be sent to the client")
+			}
+			if tc.wantPanic {
+				assert.PanicsWithValue(t, "handler panic", call)
+			} else {
+				assert.NotPanics(t, call)
+			}
+
+			assertPanicRecorded(t, sr, reader, "TestStreamServerInterceptorPanic")
+		})
+	}
+}
+
 func TestStreamServerInterceptorEvents(t *testing.T) {
 	testCases := []struct {
 		Name   string
@@ -17,10 +17,12 @@ package otelmacaron // import "go.opentelemetry.io/contrib/instrumentation/gopkg
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"gopkg.in/macaron.v1"
 
 	"go.opentelemetry.io/contrib/instrumentation/gopkg.in/macaron.v1/otelmacaron/internal/semconvutil"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
@@ -29,14 +31,21 @@ import (
 // instrumentationName is the name of this instrumentation package.
 const instrumentationName = "go.opentelemetry.io/contrib/instrumentation/gopkg.in/macaron.v1/otelmacaron"
 
-// Middleware returns a macaron Handler to trace requests to the server.
+// Middleware returns a macaron Handler to trace requests to the server and
+// record their metrics. Macaron does not expose the pattern of the matched
+// route, so the metrics do not have the http.route attribute.
 func Middleware(service string, opts ...Option) macaron.Handler {
 	cfg := newConfig(opts)
 	tracer := cfg.TracerProvider.Tracer(
 		instrumentationName,
 		oteltrace.WithInstrumentationVersion(Version()),
 	)
+	inst := newInstruments(cfg.MeterProvider.Meter(
+		instrumentationName,
+		metric.WithInstrumentationVersion(Version()),
+	))
 	return func(res http.ResponseWriter, req *http.Request, c *macaron.Context) {
+		requestStartTime := time.Now()
 		savedCtx := c.Req.Request.Context()
 		defer func() {
 			c.Req.Request = c.Req.Request.WithContext(savedCtx)
@@ -58,6 +67,11 @@ func Middleware(service string, opts ...Option) macaron.Handler {
 		// pass the span through the request context
 		c.Req.Request = c.Req.Request.WithContext(ctx)
 
+		metricAttrs := semconvutil.HTTPServerRequestMetrics(service, c.Req.Request)
+		activeAttrs := metric.WithAttributes(metricAttrs...)
+		inst.activeRequests.Add(ctx, 1, activeAttrs)
+		defer inst.activeRequests.Add(withoutCancel(ctx), -1, activeAttrs)
+
 		// serve the request to the next middleware
 		c.Next()
 
@@ -65,6 +79,17 @@ func Middleware(service string, opts ...Option) macaron.Handler {
 		span.SetStatus(semconvutil.HTTPServerStatus(status))
 		if status > 0 {
 			span.SetAttributes(semconv.HTTPStatusCode(status))
+			metricAttrs = append(metricAttrs, semconv.HTTPStatusCode(status))
+		}
+
+		ctx, o := withoutCancel(ctx), metric.WithAttributes(metricAttrs...)
+		if c.Req.ContentLength > 0 {
+			inst.requestSize.Add(ctx, c.Req.ContentLength, o)
+		}
+		if size := c.Resp.Size(); size > 0 {
+			inst.responseSize.Add(ctx, int64(size), o)
 		}
+		elapsedTime := float64(time.Since(requestStartTime)) / float64(time.Millisecond)
+		inst.duration.Record(ctx, elapsedTime, o)
 	}
 }
@@ -15,9 +15,11 @@
 package test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,6 +30,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	oteltrace "go.opentelemetry.io/otel/trace"
@@ -122,3 +125,54 @@ func TestSpanStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	// The client goes away while the request is served, which cancels the
+	// context of the request.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := macaron.Classic()
+	m.Use(otelmacaron.Middleware("foobar", otelmacaron.WithMeterProvider(provider)))
+	m.Post("/user/:id", func(ctx *macaron.Context) {
+		cancel()
+		_, _ = ctx.Resp.Write([]byte("ok"))
+	})
+
+	r := httptest.NewRequest("POST", "/user/123", strings.NewReader("hello")).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	m.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/gopkg.in/macaron.v1/otelmacaron", serverMetrics{
+		status:       http.StatusOK,
+		requestSize:  5,
+		responseSize: 2,
+	})
+}
+
+func TestMetricsErrorStatus(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	m := macaron.Classic()
+	m.Use(otelmacaron.Middleware("foobar", otelmacaron.WithMeterProvider(provider)))
+	m.Post("/user/:id", func(ctx *macaron.Context) {
+		ctx.Resp.WriteHeader(http.StatusInternalServerError)
+		_, _ = ctx.Resp.Write([]byte("error"))
+	})
+
+	r := httptest.NewRequest("POST", "/user/123", strings.NewReader("hello"))
+	w := httptest.NewRecorder()
+
+	m.ServeHTTP(w, r)
+
+	assertServerMetrics(t, reader, "go.opentelemetry.io/contrib/instrumentation/gopkg.in/macaron.v1/otelmacaron", serverMetrics{
+		status:       http.StatusInternalServerError,
+		requestSize:  5,
+		responseSize: int64(w.Body.Len()),
+	})
+}
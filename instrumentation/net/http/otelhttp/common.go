@@ -15,6 +15,8 @@
 package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
 import (
+	"context"
+	"crypto/rand"
 	"net/http"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -29,18 +31,116 @@ const (
 	WriteErrorKey = attribute.Key("http.write_error") // if an error occurred while writing a reply, the string of the error (io.EOF is not recorded)
 )
 
+// Attribute keys that can be added to the body events recorded with
+// WithBodyCapture.
+const (
+	RequestBodyKey   = attribute.Key("http.request.body")   // the captured start of the request body
+	ResponseBodyKey  = attribute.Key("http.response.body")  // the captured start of the response body
+	BodyTruncatedKey = attribute.Key("http.body.truncated") // whether the body was longer than what was captured
+)
+
 // Server HTTP metrics.
 const (
 	RequestCount          = "http.server.request_count"           // Incoming request count total
 	RequestContentLength  = "http.server.request_content_length"  // Incoming request bytes total
 	ResponseContentLength = "http.server.response_content_length" // Incoming response bytes total
 	ServerLatency         = "http.server.duration"                // Incoming end to end duration, microseconds
+	ServerActiveRequests  = "http.server.active_requests"         // Incoming requests currently being served
+)
+
+// Client HTTP metrics.
+const (
+	ClientRequestSize  = "http.client.request.size"  // Outgoing request body size, bytes
+	ClientResponseSize = "http.client.response.size" // Incoming response body size, bytes
+	ClientLatency      = "http.client.duration"      // Outgoing end to end duration, milliseconds
 )
 
 // Filter is a predicate used to determine whether a given http.request should
 // be traced. A Filter must return true if the request should be traced.
 type Filter func(*http.Request) bool
 
+// FilterDecision determines how a request is instrumented. The zero value
+// instruments the request with both a span and metrics. Decisions can be
+// combined with the bitwise OR operator.
+//
+// Unlike a request excluded by a Filter, the trace context of a request is
+// always propagated: the handler extracts it from the incoming request and
+// the transport injects it into the outgoing request.
+type FilterDecision uint8
+
+const (
+	// NoSpan means that no span is started for the request.
+	NoSpan FilterDecision = 1 << iota
+	// NoMetrics means that no metrics are recorded for the request.
+	NoMetrics
+	// UnexportedSpan means that, instead of a span from the tracer, the
+	// request keeps the span context of its parent, so that the spans of the
+	// downstream services are children of a span that is exported. A request
+	// without a parent gets a new trace that is not sampled. It has no effect
+	// if combined with NoSpan.
+	UnexportedSpan
+
+	// PropagateOnly means that the request is not instrumented, but its
+	// trace context is still propagated.
+	PropagateOnly = NoSpan | NoMetrics
+)
+
+// DecisionFilter returns the FilterDecision for a request.
+type DecisionFilter func(*http.Request) FilterDecision
+
+// decide returns the combined decision of filters for r.
+func decide(filters []DecisionFilter, r *http.Request) FilterDecision {
+	var d FilterDecision
+	for _, f := range filters {
+		d |= f(r)
+	}
+	return d
+}
+
+// unexportedSpanContext returns the span context in ctx if it is valid.
+// Otherwise, it returns the span context of a new trace that is not sampled.
+// A new span ID is never used with the parent trace flags: that span would not
+// be exported and the downstream spans of a sampled trace would be orphaned.
+func unexportedSpanContext(ctx context.Context) trace.SpanContext {
+	if parent := trace.SpanContextFromContext(ctx); parent.IsValid() {
+		return parent
+	}
+	var cfg trace.SpanContextConfig
+	_, _ = rand.Read(cfg.TraceID[:])
+	_, _ = rand.Read(cfg.SpanID[:])
+	return trace.NewSpanContext(cfg)
+}
+
+// nonRecordingSpan returns a span that does nothing but carry the span
+// context in ctx.
+func nonRecordingSpan(ctx context.Context) trace.Span {
+	return trace.SpanFromContext(trace.ContextWithSpanContext(ctx, trace.SpanContextFromContext(ctx)))
+}
+
+// capturedHeaders returns the values of the headers in h with the provided
+// canonical names, after they have been redacted by redact if it is not nil.
+func capturedHeaders(h http.Header, names []string, redact func(string, []string) []string) http.Header {
+	if len(names) == 0 || len(h) == 0 {
+		return nil
+	}
+
+	captured := make(http.Header, len(names))
+	for _, name := range names {
+		values := h.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		if redact != nil {
+			values = redact(name, append([]string(nil), values...))
+			if values == nil {
+				continue
+			}
+		}
+		captured[name] = values
+	}
+	return captured
+}
+
 func newTracer(tp trace.TracerProvider) trace.Tracer {
 	return tp.Tracer(instrumentationName, trace.WithInstrumentationVersion(Version()))
 }
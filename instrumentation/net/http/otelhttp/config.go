@@ -18,6 +18,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptrace"
+	"strings"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
@@ -42,9 +43,20 @@ type config struct {
 	ReadEvent         bool
 	WriteEvent        bool
 	Filters           []Filter
+	DecisionFilters   []DecisionFilter
 	SpanNameFormatter func(string, *http.Request) string
 	ClientTrace       func(context.Context) *httptrace.ClientTrace
 
+	RequestHeaders  []string
+	ResponseHeaders []string
+	HeaderRedactor  func(name string, values []string) []string
+
+	RecordPanics  bool
+	RecoverPanics bool
+
+	BodyCaptureLimit        int
+	BodyCaptureContentTypes []string
+
 	TracerProvider trace.TracerProvider
 	MeterProvider  metric.MeterProvider
 }
@@ -95,6 +107,10 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 
 // WithMeterProvider specifies a meter provider to use for creating a meter.
 // If none is specified, the global provider is used.
+//
+// All histograms are recorded with a context holding the span of the request
+// (or the span context it would have had if its span is not recorded), so
+// that exemplars can link their measurements to traces.
 func WithMeterProvider(provider metric.MeterProvider) Option {
 	return optionFunc(func(cfg *config) {
 		if provider != nil {
@@ -153,6 +169,16 @@ func WithFilter(f Filter) Option {
 	})
 }
 
+// WithDecisionFilter adds a DecisionFilter to the list of decision filters
+// used by the handler or transport. The decisions of all decision filters are
+// combined to determine how a request is instrumented. They are only invoked
+// for requests that are not excluded by a Filter.
+func WithDecisionFilter(f DecisionFilter) Option {
+	return optionFunc(func(c *config) {
+		c.DecisionFilters = append(c.DecisionFilters, f)
+	})
+}
+
 type event int
 
 // Different types of events that can be recorded, see WithMessageEvents.
@@ -206,3 +232,96 @@ func WithServerName(server string) Option {
 		c.ServerName = server
 	})
 }
+
+// WithRequestHeaders returns an Option that records the values of the
+// specified request headers as "http.request.header.<name>" span attributes.
+// Header names are matched case-insensitively. Headers that are not present
+// in a request are not recorded.
+//
+// Only the headers that are explicitly configured are recorded. Care should
+// be taken to not include headers containing sensitive information, or to
+// redact their values using WithHeaderRedactor.
+func WithRequestHeaders(headers ...string) Option {
+	return optionFunc(func(c *config) {
+		for _, h := range headers {
+			c.RequestHeaders = append(c.RequestHeaders, http.CanonicalHeaderKey(h))
+		}
+	})
+}
+
+// WithResponseHeaders returns an Option that records the values of the
+// specified response headers as "http.response.header.<name>" span
+// attributes. Header names are matched case-insensitively. Headers that are
+// not present in a response are not recorded.
+//
+// Only the headers that are explicitly configured are recorded. Care should
+// be taken to not include headers containing sensitive information, or to
+// redact their values using WithHeaderRedactor.
+func WithResponseHeaders(headers ...string) Option {
+	return optionFunc(func(c *config) {
+		for _, h := range headers {
+			c.ResponseHeaders = append(c.ResponseHeaders, http.CanonicalHeaderKey(h))
+		}
+	})
+}
+
+// WithHeaderRedactor returns an Option that sets the function used to redact
+// the values of headers recorded with WithRequestHeaders and
+// WithResponseHeaders. The function is called with the canonical name of the
+// header and its values, and the returned values are recorded instead. If it
+// returns nil the header is not recorded.
+//
+// This can be used to mask sensitive values, i.e. the Authorization and
+// Cookie headers, while still recording their presence.
+func WithHeaderRedactor(f func(name string, values []string) []string) Option {
+	return optionFunc(func(c *config) {
+		c.HeaderRedactor = f
+	})
+}
+
+// WithPanicRecording returns an Option that makes the Handler record panics of
+// the wrapped handler before they are propagated. The span is set to error and
+// gets an exception event with the stack trace of the panic, and the metrics
+// of the request are recorded with a 500 status code, unless the wrapped
+// handler already wrote the status code of the response.
+func WithPanicRecording() Option {
+	return optionFunc(func(c *config) {
+		c.RecordPanics = true
+	})
+}
+
+// WithPanicRecovery returns an Option that makes the Handler record panics of
+// the wrapped handler like WithPanicRecording, but instead of propagating the
+// panic it responds with a 500 status code if no response was written yet.
+//
+// Panics with http.ErrAbortHandler are always propagated as they are used to
+// abort the response.
+func WithPanicRecovery() Option {
+	return optionFunc(func(c *config) {
+		c.RecordPanics = true
+		c.RecoverPanics = true
+	})
+}
+
+// WithBodyCapture returns an Option that records up to the first limit bytes
+// of request and response bodies as span events. Only the bodies with one of
+// the provided media types (i.e. "application/json") are recorded, a media
+// type ending in "/*" (i.e. "text/*") matches all of its subtypes. If no media
+// types are provided, all bodies are recorded. The media type of a response
+// without a Content-Type header is detected from the start of its body.
+//
+// The request body of a Transport is recorded once RoundTrip returns, so only
+// the part of a streamed body read by then is recorded.
+//
+// At most limit bytes of each body are kept in memory and what is read from
+// or written to a body is not altered. As the bodies can contain sensitive
+// data, this is intended to be used for debugging.
+func WithBodyCapture(limit int, mediaTypes ...string) Option {
+	return optionFunc(func(c *config) {
+		c.BodyCaptureLimit = limit
+		c.BodyCaptureContentTypes = nil
+		for _, t := range mediaTypes {
+			c.BodyCaptureContentTypes = append(c.BodyCaptureContentTypes, strings.ToLower(t))
+		}
+	})
+}
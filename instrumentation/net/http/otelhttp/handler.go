@@ -17,6 +17,7 @@ package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http
 import (
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/felixge/httpsnoop"
@@ -42,11 +43,23 @@ type middleware struct {
 	readEvent         bool
 	writeEvent        bool
 	filters           []Filter
+	decisionFilters   []DecisionFilter
 	spanNameFormatter func(string, *http.Request) string
 	counters          map[string]metric.Int64Counter
 	valueRecorders    map[string]metric.Float64Histogram
+	upDownCounters    map[string]metric.Int64UpDownCounter
 	publicEndpoint    bool
 	publicEndpointFn  func(*http.Request) bool
+	requestHeaders    []string
+	responseHeaders   []string
+	headerRedactor    func(string, []string) []string
+	recordPanics      bool
+	recoverPanics     bool
+	bodyCaptureLimit  int
+	bodyCaptureTypes  []string
+
+	// route returns the route template matching a request, if known.
+	route func(*http.Request) string
 }
 
 func defaultHandlerFormatter(operation string, _ *http.Request) string {
@@ -63,7 +76,46 @@ func NewHandler(handler http.Handler, operation string, opts ...Option) http.Han
 // The handler returned by the middleware wraps a handler
 // in a span named after the operation and enriches it with metrics.
 func NewMiddleware(operation string, opts ...Option) func(http.Handler) http.Handler {
-	h := middleware{
+	h := newMiddleware(operation, opts...)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.serveHTTP(w, r, next)
+		})
+	}
+}
+
+// NewServeMuxHandler wraps the passed mux in a span named after the pattern
+// of mux that matches the request and enriches it with metrics. The route of
+// the pattern, without its method and host, is used as the span name and is
+// added as the HTTP route attribute to the span and metrics. If no pattern
+// matches, the span is named after the operation.
+//
+// When a span name formatter is configured with WithSpanNameFormatter, it is
+// called with the route instead of the operation.
+func NewServeMuxHandler(mux *http.ServeMux, operation string, opts ...Option) http.Handler {
+	h := newMiddleware(operation, opts...)
+	h.route = func(r *http.Request) string {
+		_, pattern := mux.Handler(r)
+		return muxRoute(pattern)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.serveHTTP(w, r, mux)
+	})
+}
+
+// muxRoute returns the path of an http.ServeMux pattern, which has the form
+// "[METHOD ][HOST]/[PATH]".
+func muxRoute(pattern string) string {
+	if i := strings.IndexByte(pattern, '/'); i >= 0 {
+		return pattern[i:]
+	}
+	return ""
+}
+
+func newMiddleware(operation string, opts ...Option) *middleware {
+	h := &middleware{
 		operation: operation,
 	}
 
@@ -76,11 +128,7 @@ func NewMiddleware(operation string, opts ...Option) func(http.Handler) http.Han
 	h.configure(c)
 	h.createMeasures()
 
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			h.serveHTTP(w, r, next)
-		})
-	}
+	return h
 }
 
 func (h *middleware) configure(c *config) {
@@ -91,10 +139,18 @@ func (h *middleware) configure(c *config) {
 	h.readEvent = c.ReadEvent
 	h.writeEvent = c.WriteEvent
 	h.filters = c.Filters
+	h.decisionFilters = c.DecisionFilters
 	h.spanNameFormatter = c.SpanNameFormatter
 	h.publicEndpoint = c.PublicEndpoint
 	h.publicEndpointFn = c.PublicEndpointFn
 	h.server = c.ServerName
+	h.requestHeaders = c.RequestHeaders
+	h.responseHeaders = c.ResponseHeaders
+	h.headerRedactor = c.HeaderRedactor
+	h.recordPanics = c.RecordPanics
+	h.recoverPanics = c.RecoverPanics
+	h.bodyCaptureLimit = c.BodyCaptureLimit
+	h.bodyCaptureTypes = c.BodyCaptureContentTypes
 }
 
 func handleErr(err error) {
@@ -106,6 +162,7 @@ func handleErr(err error) {
 func (h *middleware) createMeasures() {
 	h.counters = make(map[string]metric.Int64Counter)
 	h.valueRecorders = make(map[string]metric.Float64Histogram)
+	h.upDownCounters = make(map[string]metric.Int64UpDownCounter)
 
 	requestBytesCounter, err := h.meter.Int64Counter(RequestContentLength)
 	handleErr(err)
@@ -119,6 +176,11 @@ func (h *middleware) createMeasures() {
 	h.counters[RequestContentLength] = requestBytesCounter
 	h.counters[ResponseContentLength] = responseBytesCounter
 	h.valueRecorders[ServerLatency] = serverLatencyMeasure
+
+	activeRequestsCounter, err := h.meter.Int64UpDownCounter(ServerActiveRequests)
+	handleErr(err)
+
+	h.upDownCounters[ServerActiveRequests] = activeRequestsCounter
 }
 
 // serveHTTP sets up tracing and calls the given next http.Handler with the span
@@ -133,14 +195,27 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 		}
 	}
 
+	operation := h.operation
+	var routeAttr []attribute.KeyValue
+	if h.route != nil {
+		if route := h.route(r); route != "" {
+			operation = route
+			routeAttr = append(routeAttr, semconv.HTTPRoute(route))
+		}
+	}
+
 	ctx := h.propagators.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
 	opts := []trace.SpanStartOption{
 		trace.WithAttributes(semconvutil.HTTPServerRequest(h.server, r)...),
+		trace.WithAttributes(routeAttr...),
 	}
 	if h.server != "" {
 		hostAttr := semconv.NetHostName(h.server)
 		opts = append(opts, trace.WithAttributes(hostAttr))
 	}
+	if hdr := capturedHeaders(r.Header, h.requestHeaders, h.headerRedactor); len(hdr) > 0 {
+		opts = append(opts, trace.WithAttributes(semconvutil.HTTPRequestHeader(hdr)...))
+	}
 	opts = append(opts, h.spanStartOptions...)
 	if h.publicEndpoint || (h.publicEndpointFn != nil && h.publicEndpointFn(r.WithContext(ctx))) {
 		opts = append(opts, trace.WithNewRoot())
@@ -160,7 +235,18 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 		}
 	}
 
-	ctx, span := tracer.Start(ctx, h.spanNameFormatter(h.operation, r), opts...)
+	decision := decide(h.decisionFilters, r)
+
+	var span trace.Span
+	switch {
+	case decision&NoSpan != 0:
+		span = nonRecordingSpan(ctx)
+	case decision&UnexportedSpan != 0:
+		ctx = trace.ContextWithSpanContext(ctx, unexportedSpanContext(ctx))
+		span = trace.SpanFromContext(ctx)
+	default:
+		ctx, span = tracer.Start(ctx, h.spanNameFormatter(operation, r), opts...)
+	}
 	defer span.End()
 
 	readRecordFunc := func(int64) {}
@@ -177,6 +263,7 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 	if r.Body != nil && r.Body != http.NoBody {
 		bw.ReadCloser = r.Body
 		bw.record = readRecordFunc
+		bw.capture = newBodyCapture(h.bodyCaptureLimit, h.bodyCaptureTypes, r.Header.Get("Content-Type"))
 		r.Body = &bw
 	}
 
@@ -194,6 +281,11 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 		props:          h.propagators,
 		statusCode:     http.StatusOK, // default status code in case the Handler doesn't write anything
 	}
+	if h.bodyCaptureLimit > 0 {
+		rww.newCapture = func(contentType string) *bodyCapture {
+			return newBodyCapture(h.bodyCaptureLimit, h.bodyCaptureTypes, contentType)
+		}
+	}
 
 	// Wrap w to use our ResponseWriter methods while also exposing
 	// other interfaces that w may implement (http.CloseNotifier,
@@ -214,23 +306,80 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 	labeler := &Labeler{}
 	ctx = injectLabeler(ctx, labeler)
 
-	next.ServeHTTP(w, r.WithContext(ctx))
+	// Only low-cardinality attributes are used for the in-flight requests as
+	// the status code and any Labeler attributes are not known yet.
+	recordMetrics := decision&NoMetrics == 0
+	if recordMetrics {
+		activeAttrs := metric.WithAttributes(semconvutil.HTTPServerRequestMetrics(h.server, r)...)
+		h.upDownCounters[ServerActiveRequests].Add(ctx, 1, activeAttrs)
+		defer h.upDownCounters[ServerActiveRequests].Add(withoutCancel(ctx), -1, activeAttrs)
+	}
 
-	setAfterServeAttributes(span, bw.read, rww.written, rww.statusCode, bw.err, rww.err)
+	afterServe := func(statusCode int) {
+		if bw.capture != nil {
+			bw.capture.addEvent(span, "request.body", RequestBodyKey)
+		}
+		if rww.capture != nil {
+			rww.capture.addEvent(span, "response.body", ResponseBodyKey)
+		}
+		setAfterServeAttributes(span, bw.read.Load(), rww.written, statusCode, bw.err, rww.err)
+		if hdr := capturedHeaders(rww.Header(), h.responseHeaders, h.headerRedactor); len(hdr) > 0 {
+			span.SetAttributes(semconvutil.HTTPResponseHeader(hdr)...)
+		}
+
+		if !recordMetrics {
+			return
+		}
 
-	// Add metrics
-	attributes := append(labeler.Get(), semconvutil.HTTPServerRequest(h.server, r)...)
-	if rww.statusCode > 0 {
-		attributes = append(attributes, semconv.HTTPStatusCode(rww.statusCode))
+		// Add metrics
+		attributes := append(labeler.Get(), semconvutil.HTTPServerRequest(h.server, r)...)
+		attributes = append(attributes, routeAttr...)
+		if statusCode > 0 {
+			attributes = append(attributes, semconv.HTTPStatusCode(statusCode))
+		}
+		ctx, o := withoutCancel(ctx), metric.WithAttributes(attributes...)
+		h.counters[RequestContentLength].Add(ctx, bw.read.Load(), o)
+		h.counters[ResponseContentLength].Add(ctx, rww.written, o)
+
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedTime := float64(time.Since(requestStartTime)) / float64(time.Millisecond)
+
+		h.valueRecorders[ServerLatency].Record(ctx, elapsedTime, o)
 	}
-	o := metric.WithAttributes(attributes...)
-	h.counters[RequestContentLength].Add(ctx, bw.read, o)
-	h.counters[ResponseContentLength].Add(ctx, rww.written, o)
 
-	// Use floating point division here for higher precision (instead of Millisecond method).
-	elapsedTime := float64(time.Since(requestStartTime)) / float64(time.Millisecond)
+	if h.recordPanics {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			recovered := h.recoverPanics && rec != http.ErrAbortHandler
+			statusCode := rww.statusCode
+			if !rww.wroteHeader {
+				if recovered {
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+				statusCode = http.StatusInternalServerError
+			}
+
+			// The client received the status code the handler wrote, if any,
+			// even though the request failed.
+			afterServe(statusCode)
+			recordPanic(span, rec)
+			// End the span before the panic is propagated so the SDK does not
+			// record it a second time.
+			span.End()
+
+			if !recovered {
+				panic(rec)
+			}
+		}()
+	}
+
+	next.ServeHTTP(w, r.WithContext(ctx))
 
-	h.valueRecorders[ServerLatency].Record(ctx, elapsedTime, o)
+	afterServe(rww.statusCode)
 }
 
 func setAfterServeAttributes(span trace.Span, read, wrote int64, statusCode int, rerr, werr error) {
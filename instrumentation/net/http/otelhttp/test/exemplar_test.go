@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHistogramsRecordedWithSpanContext(t *testing.T) {
+	sampledParent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	unsampledParent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x02},
+		SpanID:  trace.SpanID{0x02},
+	})
+
+	testCases := []struct {
+		name        string
+		sampler     sdktrace.Sampler
+		parent      trace.SpanContext
+		decision    otelhttp.FilterDecision
+		wantSampled bool
+	}{
+		{name: "sampled", sampler: sdktrace.AlwaysSample(), wantSampled: true},
+		{name: "unsampled", sampler: sdktrace.NeverSample()},
+		{name: "unsampled parent", sampler: sdktrace.ParentBased(sdktrace.AlwaysSample()), parent: unsampledParent},
+		{name: "no span", sampler: sdktrace.AlwaysSample(), parent: sampledParent, decision: otelhttp.NoSpan, wantSampled: true},
+		{name: "unexported span", sampler: sdktrace.AlwaysSample(), parent: sampledParent, decision: otelhttp.UnexportedSpan, wantSampled: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := metric.NewManualReader()
+			mp := newSpanContextMeterProvider(metric.NewMeterProvider(metric.WithReader(reader)))
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(tc.sampler))
+			opts := []otelhttp.Option{
+				otelhttp.WithTracerProvider(tp),
+				otelhttp.WithMeterProvider(mp),
+				otelhttp.WithPropagators(propagation.TraceContext{}),
+				otelhttp.WithDecisionFilter(func(*http.Request) otelhttp.FilterDecision {
+					return tc.decision
+				}),
+			}
+
+			var clientSC, serverSC trace.SpanContext
+			ts := httptest.NewServer(otelhttp.NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					clientSC = trace.SpanContextFromContext(propagation.TraceContext{}.Extract(
+						context.Background(), propagation.HeaderCarrier(r.Header),
+					))
+					serverSC = trace.SpanContextFromContext(r.Context())
+					_, _ = io.WriteString(w, "response")
+				}), "server", opts...,
+			))
+			defer ts.Close()
+
+			ctx := context.Background()
+			if tc.parent.IsValid() {
+				ctx = trace.ContextWithSpanContext(ctx, tc.parent)
+			}
+			r, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.URL, strings.NewReader("request"))
+			require.NoError(t, err)
+			c := http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport, opts...)}
+			res, err := c.Do(r)
+			require.NoError(t, err)
+			_, err = io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			require.True(t, clientSC.IsValid())
+			require.True(t, serverSC.IsValid())
+			assert.Equal(t, clientSC.TraceID(), serverSC.TraceID())
+			if tc.parent.IsValid() {
+				assert.Equal(t, tc.parent.TraceID(), clientSC.TraceID())
+			}
+			if tc.decision&otelhttp.NoSpan != 0 {
+				assert.True(t, tc.parent.Equal(clientSC.WithRemote(false)), "no span should propagate the parent")
+			}
+
+			want := map[string]trace.SpanContext{
+				otelhttp.ServerLatency:      serverSC,
+				otelhttp.ClientLatency:      clientSC,
+				otelhttp.ClientRequestSize:  clientSC,
+				otelhttp.ClientResponseSize: clientSC,
+			}
+			for name, sc := range want {
+				got := mp.SpanContexts()[name]
+				if assert.Len(t, got, 1, name) {
+					assert.True(t, sc.Equal(got[0].WithRemote(sc.IsRemote())), "%s recorded with %v, want %v", name, got[0], sc)
+					assert.Equal(t, tc.wantSampled, got[0].IsSampled(), name)
+				}
+			}
+
+			// The measurements still reach the reader of the metric SDK.
+			rm := metricdata.ResourceMetrics{}
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			histograms := map[string]bool{}
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				switch m.Data.(type) {
+				case metricdata.Histogram[int64], metricdata.Histogram[float64]:
+					histograms[m.Name] = true
+				}
+			}
+			for name := range want {
+				assert.True(t, histograms[name], "missing histogram %s", name)
+			}
+		})
+	}
+}
@@ -41,13 +41,13 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
-func assertScopeMetrics(t *testing.T, sm metricdata.ScopeMetrics, attrs attribute.Set) {
+func assertScopeMetrics(t *testing.T, sm metricdata.ScopeMetrics, attrs, activeAttrs attribute.Set) {
 	assert.Equal(t, instrumentation.Scope{
 		Name:    "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp",
 		Version: otelhttp.Version(),
 	}, sm.Scope)
 
-	require.Len(t, sm.Metrics, 3)
+	require.Len(t, sm.Metrics, 4)
 
 	want := metricdata.Metrics{
 		Name: "http.server.request_content_length",
@@ -80,6 +80,16 @@ func assertScopeMetrics(t *testing.T, sm metricdata.ScopeMetrics, attrs attribut
 	assert.Equal(t, attrs, dPt.Attributes, "attributes")
 	assert.Equal(t, uint64(1), dPt.Count, "count")
 	assert.Equal(t, []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}, dPt.Bounds, "bounds")
+
+	want = metricdata.Metrics{
+		Name: "http.server.active_requests",
+		Data: metricdata.Sum[int64]{
+			DataPoints:  []metricdata.DataPoint[int64]{{Attributes: activeAttrs, Value: 0}},
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: false,
+		},
+	}
+	metricdatatest.AssertEqual(t, want, sm.Metrics[3], metricdatatest.IgnoreTimestamp())
 }
 
 func TestHandlerBasics(t *testing.T) {
@@ -123,7 +133,12 @@ func TestHandlerBasics(t *testing.T) {
 		attribute.String("test", "attribute"),
 		semconv.HTTPStatusCode(200),
 	)
-	assertScopeMetrics(t, rm.ScopeMetrics[0], attrs)
+	activeAttrs := attribute.NewSet(
+		semconv.HTTPMethod("GET"),
+		semconv.HTTPSchemeHTTP,
+		semconv.NetHostName(r.Host),
+	)
+	assertScopeMetrics(t, rm.ScopeMetrics[0], attrs, activeAttrs)
 
 	if got, expected := rr.Result().StatusCode, http.StatusOK; got != expected {
 		t.Fatalf("got %d, expected %d", got, expected)
@@ -505,6 +520,10 @@ func TestWithRouteTag(t *testing.T) {
 	gotMetrics := rm.ScopeMetrics[0].Metrics
 
 	for _, m := range gotMetrics {
+		if m.Name == otelhttp.ServerActiveRequests {
+			// The route is not known before the request is handled.
+			continue
+		}
 		switch d := m.Data.(type) {
 		case metricdata.Sum[int64]:
 			require.Len(t, d.DataPoints, 1, "metric '%v' should have exactly one data point", m.Name)
@@ -535,3 +554,438 @@ func TestWithRouteTag(t *testing.T) {
 		}
 	}
 }
+
+func TestHandlerActiveRequests(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	collectActive := func() int64 {
+		rm := metricdata.ResourceMetrics{}
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name != otelhttp.ServerActiveRequests {
+					continue
+				}
+				sum, ok := m.Data.(metricdata.Sum[int64])
+				require.True(t, ok, "unexpected data type %T", m.Data)
+				require.Len(t, sum.DataPoints, 1)
+				return sum.DataPoints[0].Value
+			}
+		}
+		return 0
+	}
+
+	var inFlight int64
+	ctx, cancelRequest := context.WithCancel(context.Background())
+	defer cancelRequest()
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			inFlight = collectActive()
+			switch r.URL.Path {
+			case "/panic":
+				panic("handler panic")
+			case "/abort":
+				// The server cancels the request context when the client
+				// goes away.
+				cancelRequest()
+			}
+		}), "test_handler",
+		otelhttp.WithMeterProvider(meterProvider),
+	)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, int64(1), inFlight, "request should be in flight while handled")
+	assert.Equal(t, int64(0), collectActive(), "request should not be in flight after handling")
+
+	assert.Panics(t, func() {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/panic", nil))
+	})
+	assert.Equal(t, int64(1), inFlight, "request should be in flight while handled")
+	assert.Equal(t, int64(0), collectActive(), "panicking request should not be in flight after handling")
+
+	r := httptest.NewRequest(http.MethodGet, "/abort", nil).WithContext(ctx)
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, int64(1), inFlight, "request should be in flight while handled")
+	assert.Equal(t, int64(0), collectActive(), "aborted request should not be in flight after handling")
+}
+
+func TestHandlerCapturedHeaders(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Response-Id", "abc")
+			w.Header().Set("Set-Cookie", "session=secret")
+			w.Header().Set("X-Not-Captured", "value")
+		}), "test_handler",
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithRequestHeaders("x-request-id", "AUTHORIZATION", "X-Missing"),
+		otelhttp.WithResponseHeaders("x-response-id", "set-cookie"),
+		otelhttp.WithHeaderRedactor(func(name string, values []string) []string {
+			switch name {
+			case "Authorization", "Set-Cookie":
+				for i := range values {
+					values[i] = "REDACTED"
+				}
+			}
+			return values
+		}),
+	)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("X-Request-Id", "1")
+	r.Header.Add("X-Request-Id", "2")
+	r.Header.Set("Authorization", "Bearer secret")
+	r.Header.Set("X-Not-Captured", "value")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	require.Len(t, spanRecorder.Ended(), 1)
+	attrs := spanRecorder.Ended()[0].Attributes()
+	assert.Contains(t, attrs, attribute.StringSlice("http.request.header.x_request_id", []string{"1", "2"}))
+	assert.Contains(t, attrs, attribute.StringSlice("http.request.header.authorization", []string{"REDACTED"}))
+	assert.Contains(t, attrs, attribute.StringSlice("http.response.header.x_response_id", []string{"abc"}))
+	assert.Contains(t, attrs, attribute.StringSlice("http.response.header.set_cookie", []string{"REDACTED"}))
+	for _, kv := range attrs {
+		assert.NotContains(t, string(kv.Key), "x_not_captured")
+		assert.NotContains(t, string(kv.Key), "x_missing")
+	}
+	assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"), "request header should not be modified")
+}
+
+func TestHandlerPanicRecording(t *testing.T) {
+	testCases := []struct {
+		name         string
+		opt          otelhttp.Option
+		wroteStatus  int
+		wantPanic    bool
+		wantStatus   int
+		wantRecorded int
+	}{
+		{
+			name:         "recording",
+			opt:          otelhttp.WithPanicRecording(),
+			wantPanic:    true,
+			wantStatus:   http.StatusOK, // Set by the httptest.ResponseRecorder.
+			wantRecorded: http.StatusInternalServerError,
+		},
+		{
+			name:         "recovery",
+			opt:          otelhttp.WithPanicRecovery(),
+			wantStatus:   http.StatusInternalServerError,
+			wantRecorded: http.StatusInternalServerError,
+		},
+		{
+			name:         "recovery after header written",
+			opt:          otelhttp.WithPanicRecovery(),
+			wroteStatus:  http.StatusAccepted,
+			wantStatus:   http.StatusAccepted,
+			wantRecorded: http.StatusAccepted,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+			reader := metric.NewManualReader()
+			meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+			h := otelhttp.NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if tc.wroteStatus != 0 {
+						w.WriteHeader(tc.wroteStatus)
+					}
+					panic("handler panic")
+				}), "test_handler",
+				otelhttp.WithTracerProvider(provider),
+				otelhttp.WithMeterProvider(meterProvider),
+				tc.opt,
+			)
+
+			rr := httptest.NewRecorder()
+			serve := func() { h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil)) }
+			if tc.wantPanic {
+				assert.PanicsWithValue(t, "handler panic", serve)
+			} else {
+				assert.NotPanics(t, serve)
+			}
+			assert.Equal(t, tc.wantStatus, rr.Result().StatusCode)
+
+			spans := spanRecorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, codes.Error, spans[0].Status().Code)
+			assert.Equal(t, "handler panic", spans[0].Status().Description)
+			assert.Contains(t, spans[0].Attributes(), semconv.HTTPStatusCode(tc.wantRecorded))
+			require.Len(t, spans[0].Events(), 1)
+			event := spans[0].Events()[0]
+			assert.Equal(t, semconv.ExceptionEventName, event.Name)
+			assert.Contains(t, event.Attributes, semconv.ExceptionType("string"))
+			assert.Contains(t, event.Attributes, semconv.ExceptionMessage("handler panic"))
+			attrs := attribute.NewSet(event.Attributes...)
+			stack, _ := attrs.Value(semconv.ExceptionStacktraceKey)
+			assert.Contains(t, stack.AsString(), "TestHandlerPanicRecording")
+
+			rm := metricdata.ResourceMetrics{}
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				if m.Name != otelhttp.ServerLatency {
+					continue
+				}
+				hist, ok := m.Data.(metricdata.Histogram[float64])
+				require.True(t, ok, "unexpected data type %T", m.Data)
+				require.Len(t, hist.DataPoints, 1)
+				v, ok := hist.DataPoints[0].Attributes.Value(semconv.HTTPStatusCodeKey)
+				assert.True(t, ok)
+				assert.Equal(t, int64(tc.wantRecorded), v.AsInt64())
+			}
+		})
+	}
+}
+
+func TestHandlerBodyCapture(t *testing.T) {
+	const reqBody = `{"name":"request body"}`
+
+	testCases := []struct {
+		name         string
+		respType     string
+		respBody     string
+		wantEvents   []sdktrace.Event
+		wantRespBody string
+	}{
+		{
+			name:     "allowed response",
+			respType: "application/json; charset=utf-8",
+			respBody: `{}`,
+			wantEvents: []sdktrace.Event{
+				{Name: "request.body", Attributes: []attribute.KeyValue{
+					otelhttp.RequestBodyKey.String(reqBody[:10]),
+					otelhttp.BodyTruncatedKey.Bool(true),
+				}},
+				{Name: "response.body", Attributes: []attribute.KeyValue{
+					otelhttp.ResponseBodyKey.String(`{}`),
+					otelhttp.BodyTruncatedKey.Bool(false),
+				}},
+			},
+		},
+		{
+			name:     "not allowed response",
+			respType: "text/plain",
+			respBody: "response body",
+			wantEvents: []sdktrace.Event{
+				{Name: "request.body", Attributes: []attribute.KeyValue{
+					otelhttp.RequestBodyKey.String(reqBody[:10]),
+					otelhttp.BodyTruncatedKey.Bool(true),
+				}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+			var gotReqBody []byte
+			h := otelhttp.NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var err error
+					gotReqBody, err = io.ReadAll(r.Body)
+					require.NoError(t, err)
+					w.Header().Set("Content-Type", tc.respType)
+					_, err = io.WriteString(w, tc.respBody)
+					require.NoError(t, err)
+				}), "test_handler",
+				otelhttp.WithTracerProvider(provider),
+				otelhttp.WithBodyCapture(10, "application/json"),
+			)
+
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(reqBody))
+			r.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, r)
+
+			assert.Equal(t, reqBody, string(gotReqBody), "request body should not be altered")
+			assert.Equal(t, tc.respBody, rr.Body.String(), "response body should not be altered")
+
+			require.Len(t, spanRecorder.Ended(), 1)
+			events := spanRecorder.Ended()[0].Events()
+			require.Len(t, events, len(tc.wantEvents))
+			for i, want := range tc.wantEvents {
+				assert.Equal(t, want.Name, events[i].Name)
+				assert.ElementsMatch(t, want.Attributes, events[i].Attributes)
+			}
+		})
+	}
+}
+
+func TestHandlerBodyCaptureDetectedType(t *testing.T) {
+	const respBody = "<html></html>"
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+	// The response has no Content-Type, which is detected from its body
+	// once it is written after the header.
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			_, err := io.WriteString(w, respBody)
+			require.NoError(t, err)
+		}), "test_handler",
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithBodyCapture(100, "text/html"),
+	)
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.Len(t, spanRecorder.Ended(), 1)
+	events := spanRecorder.Ended()[0].Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, "response.body", events[0].Name)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		otelhttp.ResponseBodyKey.String(respBody),
+		otelhttp.BodyTruncatedKey.Bool(false),
+	}, events[0].Attributes)
+}
+
+func TestServeMuxHandler(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {})
+
+	h := otelhttp.NewServeMuxHandler(mux, "test_handler",
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithMeterProvider(meterProvider),
+	)
+
+	for _, target := range []string{"/users/42", "/health", "/missing"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "/users/", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPRoute("/users/"))
+	assert.Equal(t, "/health", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), semconv.HTTPRoute("/health"))
+	assert.Equal(t, "test_handler", spans[2].Name())
+	for _, kv := range spans[2].Attributes() {
+		assert.NotEqual(t, semconv.HTTPRouteKey, kv.Key, "unmatched request should not have a route")
+	}
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	var routes []string
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != otelhttp.ServerLatency {
+			continue
+		}
+		hist, ok := m.Data.(metricdata.Histogram[float64])
+		require.True(t, ok, "unexpected data type %T", m.Data)
+		for _, dp := range hist.DataPoints {
+			if v, ok := dp.Attributes.Value(semconv.HTTPRouteKey); ok {
+				routes = append(routes, v.AsString())
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"/users/", "/health"}, routes)
+}
+
+func TestHandlerDecisionFilter(t *testing.T) {
+	const traceparent = "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01"
+	prop := propagation.TraceContext{}
+	remote := trace.SpanContextFromContext(prop.Extract(
+		context.Background(),
+		propagation.MapCarrier{"traceparent": traceparent},
+	))
+
+	testCases := []struct {
+		decision    otelhttp.FilterDecision
+		wantSpan    bool
+		wantMetrics bool
+		// wantParent is whether the handler sees the remote span context,
+		// which is the parent of its spans.
+		wantParent bool
+	}{
+		{decision: 0, wantSpan: true, wantMetrics: true},
+		{decision: otelhttp.NoSpan, wantMetrics: true, wantParent: true},
+		{decision: otelhttp.NoMetrics, wantSpan: true},
+		{decision: otelhttp.UnexportedSpan, wantMetrics: true, wantParent: true},
+		{decision: otelhttp.PropagateOnly, wantParent: true},
+		{decision: otelhttp.NoSpan | otelhttp.UnexportedSpan, wantMetrics: true, wantParent: true},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%b", tc.decision), func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+			reader := metric.NewManualReader()
+			meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+			var got trace.SpanContext
+			h := otelhttp.NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					got = trace.SpanContextFromContext(r.Context())
+				}), "test_handler",
+				otelhttp.WithTracerProvider(provider),
+				otelhttp.WithMeterProvider(meterProvider),
+				otelhttp.WithPropagators(prop),
+				otelhttp.WithDecisionFilter(func(*http.Request) otelhttp.FilterDecision {
+					return tc.decision
+				}),
+			)
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("traceparent", traceparent)
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			if tc.wantSpan {
+				assert.Len(t, spanRecorder.Ended(), 1)
+			} else {
+				assert.Len(t, spanRecorder.Ended(), 0)
+			}
+
+			rm := metricdata.ResourceMetrics{}
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			if tc.wantMetrics {
+				assert.Len(t, rm.ScopeMetrics, 1)
+			} else {
+				assert.Len(t, rm.ScopeMetrics, 0)
+			}
+
+			assert.Equal(t, remote.TraceID(), got.TraceID(), "trace should be propagated")
+			assert.True(t, got.IsSampled(), "sampled flag should be propagated")
+			if tc.wantParent {
+				assert.Equal(t, remote.SpanID(), got.SpanID())
+			} else {
+				assert.NotEqual(t, remote.SpanID(), got.SpanID())
+			}
+		})
+	}
+}
+
+func TestHandlerUnexportedSpanWithoutParent(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+	var got trace.SpanContext
+	h := otelhttp.NewHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = trace.SpanContextFromContext(r.Context())
+		}), "test_handler",
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithPropagators(propagation.TraceContext{}),
+		otelhttp.WithDecisionFilter(func(*http.Request) otelhttp.FilterDecision {
+			return otelhttp.UnexportedSpan
+		}),
+	)
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Len(t, spanRecorder.Ended(), 0)
+	assert.True(t, got.IsValid(), "a new trace should be started")
+	assert.False(t, got.IsSampled(), "the new trace should not be sampled")
+}
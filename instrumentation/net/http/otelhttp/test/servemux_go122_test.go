@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.22
+
+// The method and wildcard patterns of http.ServeMux are disabled by default
+// for modules declaring a Go version before 1.22.
+//go:debug httpmuxgo121=0
+
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+func TestServeMuxHandlerPatterns(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("example.com/files/{path...}", func(w http.ResponseWriter, r *http.Request) {})
+
+	h := otelhttp.NewServeMuxHandler(mux, "test_handler",
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithMeterProvider(meterProvider),
+	)
+
+	for _, r := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/users/42", nil),
+		httptest.NewRequest(http.MethodGet, "http://example.com/files/a/b.txt", nil),
+		httptest.NewRequest(http.MethodPost, "/users/42", nil),
+	} {
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "/users/{id}", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPRoute("/users/{id}"))
+	assert.Equal(t, "/files/{path...}", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), semconv.HTTPRoute("/files/{path...}"))
+	assert.Equal(t, "test_handler", spans[2].Name(), "request with a method not allowed should not match")
+	for _, kv := range spans[2].Attributes() {
+		assert.NotEqual(t, semconv.HTTPRouteKey, kv.Key, "unmatched request should not have a route")
+	}
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	routes := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != otelhttp.ServerLatency {
+			continue
+		}
+		hist, ok := m.Data.(metricdata.Histogram[float64])
+		require.True(t, ok, "unexpected data type %T", m.Data)
+		for _, dp := range hist.DataPoints {
+			route, _ := dp.Attributes.Value(semconv.HTTPRouteKey)
+			status, _ := dp.Attributes.Value(semconv.HTTPStatusCodeKey)
+			routes[route.AsString()] = status.AsInt64()
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"/users/{id}":      http.StatusOK,
+		"/files/{path...}": http.StatusOK,
+		"":                 http.StatusMethodNotAllowed,
+	}, routes)
+}
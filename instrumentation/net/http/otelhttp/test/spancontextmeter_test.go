@@ -0,0 +1,102 @@
+// Code created by gotmpl. DO NOT MODIFY.
+// source: internal/shared/spancontextmeter/spancontextmeter_test.go.tmpl
+
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"sync"
+
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanContextMeterProvider wraps a MeterProvider to keep the span context of
+// the context of every histogram measurement, which is what the exemplar
+// reservoirs of the metric SDK sample from.
+type spanContextMeterProvider struct {
+	otelmetric.MeterProvider
+
+	mu           sync.Mutex
+	spanContexts map[string][]trace.SpanContext
+}
+
+func newSpanContextMeterProvider(mp otelmetric.MeterProvider) *spanContextMeterProvider {
+	return &spanContextMeterProvider{
+		MeterProvider: mp,
+		spanContexts:  make(map[string][]trace.SpanContext),
+	}
+}
+
+func (p *spanContextMeterProvider) Meter(name string, opts ...otelmetric.MeterOption) otelmetric.Meter {
+	return &spanContextMeter{Meter: p.MeterProvider.Meter(name, opts...), p: p}
+}
+
+func (p *spanContextMeterProvider) add(name string, ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.spanContexts[name] = append(p.spanContexts[name], trace.SpanContextFromContext(ctx))
+}
+
+// SpanContexts returns the span contexts of all histogram measurements by
+// the name of their histogram.
+func (p *spanContextMeterProvider) SpanContexts() map[string][]trace.SpanContext {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	m := make(map[string][]trace.SpanContext, len(p.spanContexts))
+	for k, v := range p.spanContexts {
+		m[k] = append([]trace.SpanContext(nil), v...)
+	}
+	return m
+}
+
+type spanContextMeter struct {
+	otelmetric.Meter
+	p *spanContextMeterProvider
+}
+
+func (m *spanContextMeter) Int64Histogram(name string, opts ...otelmetric.Int64HistogramOption) (otelmetric.Int64Histogram, error) {
+	h, err := m.Meter.Int64Histogram(name, opts...)
+	return &int64Histogram{Int64Histogram: h, name: name, p: m.p}, err
+}
+
+func (m *spanContextMeter) Float64Histogram(name string, opts ...otelmetric.Float64HistogramOption) (otelmetric.Float64Histogram, error) {
+	h, err := m.Meter.Float64Histogram(name, opts...)
+	return &float64Histogram{Float64Histogram: h, name: name, p: m.p}, err
+}
+
+type int64Histogram struct {
+	otelmetric.Int64Histogram
+	name string
+	p    *spanContextMeterProvider
+}
+
+func (h *int64Histogram) Record(ctx context.Context, v int64, opts ...otelmetric.RecordOption) {
+	h.p.add(h.name, ctx)
+	h.Int64Histogram.Record(ctx, v, opts...)
+}
+
+type float64Histogram struct {
+	otelmetric.Float64Histogram
+	name string
+	p    *spanContextMeterProvider
+}
+
+func (h *float64Histogram) Record(ctx context.Context, v float64, opts ...otelmetric.RecordOption) {
+	h.p.add(h.name, ctx)
+	h.Float64Histogram.Record(ctx, v, opts...)
+}
@@ -15,12 +15,16 @@
 package test
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httptrace"
+	"net/url"
 	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -28,10 +32,15 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -238,3 +247,313 @@ func TestWithHTTPTrace(t *testing.T) {
 	assert.Equal(t, spans[2].SpanContext().SpanID(), spans[0].Parent().SpanID())
 	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[2].Parent().SpanID())
 }
+
+func TestTransportMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	requestBody := []byte("ping")
+	responseBody := []byte("Hello, world!")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		_, err = w.Write(responseBody)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	r, err := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader(requestBody))
+	require.NoError(t, err)
+
+	tr := otelhttp.NewTransport(
+		http.DefaultTransport,
+		otelhttp.WithMeterProvider(meterProvider),
+	)
+
+	c := http.Client{Transport: tr}
+	res, err := c.Do(r)
+	require.NoError(t, err)
+
+	// Nothing is recorded until the response body has been consumed.
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	for _, sm := range rm.ScopeMetrics {
+		assert.Empty(t, sm.Metrics)
+	}
+
+	_, err = io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sm := rm.ScopeMetrics[0]
+	require.Len(t, sm.Metrics, 3)
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+	attrs := attribute.NewSet(
+		semconv.HTTPMethod(http.MethodPost),
+		semconv.HTTPFlavorKey.String("1.1"),
+		semconv.NetPeerName(u.Hostname()),
+		semconv.NetPeerPort(port),
+		semconv.HTTPStatusCode(http.StatusOK),
+	)
+
+	want := metricdata.Metrics{
+		Name:        "http.client.request.size",
+		Description: "Measures the size of HTTP request messages.",
+		Unit:        "By",
+		Data: metricdata.Histogram[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints: []metricdata.HistogramDataPoint[int64]{{
+				Attributes:   attrs,
+				Count:        1,
+				Bounds:       []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000},
+				BucketCounts: []uint64{0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+				Sum:          int64(len(requestBody)),
+				Min:          metricdata.NewExtrema(int64(len(requestBody))),
+				Max:          metricdata.NewExtrema(int64(len(requestBody))),
+			}},
+		},
+	}
+	metricdatatest.AssertEqual(t, want, sm.Metrics[0], metricdatatest.IgnoreTimestamp())
+
+	assert.Equal(t, "http.client.response.size", sm.Metrics[1].Name)
+	hist := sm.Metrics[1].Data.(metricdata.Histogram[int64])
+	require.Len(t, hist.DataPoints, 1)
+	assert.Equal(t, attrs, hist.DataPoints[0].Attributes, "attributes")
+	assert.Equal(t, int64(len(responseBody)), hist.DataPoints[0].Sum, "response size")
+
+	// Duration value is not predictable.
+	assert.Equal(t, "http.client.duration", sm.Metrics[2].Name)
+	assert.Equal(t, "ms", sm.Metrics[2].Unit)
+	dur := sm.Metrics[2].Data.(metricdata.Histogram[float64])
+	require.Len(t, dur.DataPoints, 1)
+	assert.Equal(t, attrs, dur.DataPoints[0].Attributes, "attributes")
+	assert.Equal(t, uint64(1), dur.DataPoints[0].Count, "count")
+}
+
+func TestTransportMetricsCanceled(t *testing.T) {
+	reader := metric.NewManualReader()
+	meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	responseBody := []byte("Hello, ")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write(responseBody)
+		require.NoError(t, err)
+		w.(http.Flusher).Flush()
+		// The rest of the body is never sent.
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+
+	c := http.Client{Transport: otelhttp.NewTransport(
+		http.DefaultTransport,
+		otelhttp.WithMeterProvider(meterProvider),
+	)}
+	res, err := c.Do(r)
+	require.NoError(t, err)
+
+	// The request is canceled while its response body is read.
+	_, err = io.ReadFull(res.Body, make([]byte, len(responseBody)))
+	require.NoError(t, err)
+	cancel()
+	_, err = io.ReadAll(res.Body)
+	assert.ErrorIs(t, err, context.Canceled)
+	require.NoError(t, res.Body.Close())
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	dur, ok := metrics["http.client.duration"].(metricdata.Histogram[float64])
+	require.True(t, ok, "duration of the canceled request not recorded")
+	require.Len(t, dur.DataPoints, 1)
+	assert.Equal(t, uint64(1), dur.DataPoints[0].Count)
+
+	size, ok := metrics["http.client.response.size"].(metricdata.Histogram[int64])
+	require.True(t, ok, "response size of the canceled request not recorded")
+	require.Len(t, size.DataPoints, 1)
+	assert.Equal(t, int64(len(responseBody)), size.DataPoints[0].Sum)
+}
+
+func TestTransportCapturedHeaders(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("X-Not-Captured", "value")
+	}))
+	defer ts.Close()
+
+	r, err := http.NewRequest(http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+	r.Header.Set("Cookie", "session=secret")
+	r.Header.Set("X-Not-Captured", "value")
+
+	tr := otelhttp.NewTransport(
+		http.DefaultTransport,
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithRequestHeaders("cookie"),
+		otelhttp.WithResponseHeaders("content-type"),
+		otelhttp.WithHeaderRedactor(func(name string, values []string) []string {
+			if name == "Cookie" {
+				return nil
+			}
+			return values
+		}),
+	)
+
+	c := http.Client{Transport: tr}
+	res, err := c.Do(r)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	require.Len(t, spanRecorder.Ended(), 1)
+	attrs := spanRecorder.Ended()[0].Attributes()
+	assert.Contains(t, attrs, attribute.StringSlice("http.response.header.content_type", []string{"text/plain"}))
+	for _, kv := range attrs {
+		assert.NotEqual(t, attribute.Key("http.request.header.cookie"), kv.Key, "redacted header should not be recorded")
+		assert.NotContains(t, string(kv.Key), "x_not_captured")
+	}
+}
+
+func TestTransportBodyCapture(t *testing.T) {
+	const (
+		reqBody  = "request body"
+		respBody = "response body"
+	)
+
+	spanRecorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, reqBody, string(b), "request body should not be altered")
+		w.Header().Set("Content-Type", "text/plain")
+		_, err = io.WriteString(w, respBody)
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	r, err := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader(reqBody))
+	require.NoError(t, err)
+	r.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	tr := otelhttp.NewTransport(
+		http.DefaultTransport,
+		otelhttp.WithTracerProvider(provider),
+		otelhttp.WithBodyCapture(8, "text/*"),
+	)
+
+	c := http.Client{Transport: tr}
+	res, err := c.Do(r)
+	require.NoError(t, err)
+	b, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, respBody, string(b), "response body should not be altered")
+
+	require.Len(t, spanRecorder.Ended(), 1)
+	events := spanRecorder.Ended()[0].Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, "request.body", events[0].Name)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		otelhttp.RequestBodyKey.String(reqBody[:8]),
+		otelhttp.BodyTruncatedKey.Bool(true),
+	}, events[0].Attributes)
+	assert.Equal(t, "response.body", events[1].Name)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		otelhttp.ResponseBodyKey.String(respBody[:8]),
+		otelhttp.BodyTruncatedKey.Bool(true),
+	}, events[1].Attributes)
+}
+
+func TestTransportDecisionFilter(t *testing.T) {
+	prop := propagation.TraceContext{}
+	var got trace.SpanContext
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = trace.SpanContextFromContext(prop.Extract(r.Context(), propagation.HeaderCarrier(r.Header)))
+	}))
+	defer ts.Close()
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), parent)
+
+	testCases := []struct {
+		decision    otelhttp.FilterDecision
+		wantSpan    bool
+		wantMetrics bool
+		wantParent  bool // whether the server receives the span context of the caller
+	}{
+		{decision: 0, wantSpan: true, wantMetrics: true},
+		{decision: otelhttp.NoMetrics, wantSpan: true},
+		{decision: otelhttp.UnexportedSpan, wantMetrics: true, wantParent: true},
+		{decision: otelhttp.PropagateOnly, wantParent: true},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%b", tc.decision), func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+			reader := metric.NewManualReader()
+			meterProvider := metric.NewMeterProvider(metric.WithReader(reader))
+
+			tr := otelhttp.NewTransport(
+				http.DefaultTransport,
+				otelhttp.WithTracerProvider(provider),
+				otelhttp.WithMeterProvider(meterProvider),
+				otelhttp.WithPropagators(prop),
+				otelhttp.WithDecisionFilter(func(*http.Request) otelhttp.FilterDecision {
+					return tc.decision
+				}),
+			)
+
+			r, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+			require.NoError(t, err)
+			res, err := tr.RoundTrip(r)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			if tc.wantSpan {
+				assert.Len(t, spanRecorder.Ended(), 1)
+			} else {
+				assert.Len(t, spanRecorder.Ended(), 0)
+			}
+
+			rm := metricdata.ResourceMetrics{}
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			if tc.wantMetrics {
+				assert.Len(t, rm.ScopeMetrics, 1)
+			} else {
+				assert.Len(t, rm.ScopeMetrics, 0)
+			}
+
+			assert.Equal(t, parent.TraceID(), got.TraceID(), "trace should be propagated")
+			assert.True(t, got.IsSampled(), "sampled flag should be propagated")
+			if tc.wantParent {
+				assert.Equal(t, parent.SpanID(), got.SpanID())
+			} else {
+				assert.NotEqual(t, parent.SpanID(), got.SpanID())
+			}
+		})
+	}
+}
@@ -19,25 +19,42 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/internal/semconvutil"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
 // Transport implements the http.RoundTripper interface and wraps
-// outbound HTTP(S) requests with a span.
+// outbound HTTP(S) requests with a span and enriches it with metrics.
 type Transport struct {
 	rt http.RoundTripper
 
 	tracer            trace.Tracer
+	meter             metric.Meter
 	propagators       propagation.TextMapPropagator
 	spanStartOptions  []trace.SpanStartOption
 	filters           []Filter
+	decisionFilters   []DecisionFilter
 	spanNameFormatter func(string, *http.Request) string
 	clientTrace       func(context.Context) *httptrace.ClientTrace
+	requestHeaders    []string
+	responseHeaders   []string
+	headerRedactor    func(string, []string) []string
+	bodyCaptureLimit  int
+	bodyCaptureTypes  []string
+
+	requestSize  metric.Int64Histogram
+	responseSize metric.Int64Histogram
+	latency      metric.Float64Histogram
 }
 
 var _ http.RoundTripper = &Transport{}
@@ -63,17 +80,49 @@ func NewTransport(base http.RoundTripper, opts ...Option) *Transport {
 
 	c := newConfig(append(defaultOpts, opts...)...)
 	t.applyConfig(c)
+	t.createMeasures()
 
 	return &t
 }
 
 func (t *Transport) applyConfig(c *config) {
 	t.tracer = c.Tracer
+	t.meter = c.Meter
 	t.propagators = c.Propagators
 	t.spanStartOptions = c.SpanStartOptions
 	t.filters = c.Filters
+	t.decisionFilters = c.DecisionFilters
 	t.spanNameFormatter = c.SpanNameFormatter
 	t.clientTrace = c.ClientTrace
+	t.requestHeaders = c.RequestHeaders
+	t.responseHeaders = c.ResponseHeaders
+	t.headerRedactor = c.HeaderRedactor
+	t.bodyCaptureLimit = c.BodyCaptureLimit
+	t.bodyCaptureTypes = c.BodyCaptureContentTypes
+}
+
+func (t *Transport) createMeasures() {
+	var err error
+	t.requestSize, err = t.meter.Int64Histogram(
+		ClientRequestSize,
+		metric.WithUnit("By"),
+		metric.WithDescription("Measures the size of HTTP request messages."),
+	)
+	handleErr(err)
+
+	t.responseSize, err = t.meter.Int64Histogram(
+		ClientResponseSize,
+		metric.WithUnit("By"),
+		metric.WithDescription("Measures the size of HTTP response messages."),
+	)
+	handleErr(err)
+
+	t.latency, err = t.meter.Float64Histogram(
+		ClientLatency,
+		metric.WithUnit("ms"),
+		metric.WithDescription("Measures the duration of outbound HTTP requests."),
+	)
+	handleErr(err)
 }
 
 func defaultTransportFormatter(_ string, r *http.Request) string {
@@ -83,6 +132,7 @@ func defaultTransportFormatter(_ string, r *http.Request) string {
 // RoundTrip creates a Span and propagates its context via the provided request's headers
 // before handing the request to the configured base RoundTripper. The created span will
 // end when the response body is closed or when a read from the body returns io.EOF.
+// The duration and size metrics of the request are recorded at the same time.
 func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 	for _, f := range t.filters {
 		if !f(r) {
@@ -91,6 +141,8 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 		}
 	}
 
+	requestStartTime := time.Now()
+
 	tracer := t.tracer
 
 	if tracer == nil {
@@ -103,58 +155,132 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 
 	opts := append([]trace.SpanStartOption{}, t.spanStartOptions...) // start with the configured options
 
-	ctx, span := tracer.Start(r.Context(), t.spanNameFormatter("", r), opts...)
+	decision := decide(t.decisionFilters, r)
+
+	ctx := r.Context()
+	var span trace.Span
+	switch {
+	case decision&NoSpan != 0:
+		span = nonRecordingSpan(ctx)
+	case decision&UnexportedSpan != 0:
+		ctx = trace.ContextWithSpanContext(ctx, unexportedSpanContext(ctx))
+		span = trace.SpanFromContext(ctx)
+	default:
+		ctx, span = tracer.Start(ctx, t.spanNameFormatter("", r), opts...)
+	}
 
 	if t.clientTrace != nil {
 		ctx = httptrace.WithClientTrace(ctx, t.clientTrace(ctx))
 	}
 
 	r = r.Clone(ctx) // According to RoundTripper spec, we shouldn't modify the origin request.
+
+	// if request body is nil or NoBody, we don't want to mutate the body as it
+	// will affect the identity of it in an unforeseeable way because we assert
+	// ReadCloser fulfills a certain interface and it is indeed nil or NoBody.
+	bw := &bodyWrapper{record: func(int64) {}}
+	if r.Body != nil && r.Body != http.NoBody {
+		bw.ReadCloser = r.Body
+		bw.capture = newBodyCapture(t.bodyCaptureLimit, t.bodyCaptureTypes, r.Header.Get("Content-Type"))
+		r.Body = bw
+	}
+
 	span.SetAttributes(semconvutil.HTTPClientRequest(r)...)
+	if hdr := capturedHeaders(r.Header, t.requestHeaders, t.headerRedactor); len(hdr) > 0 {
+		span.SetAttributes(semconvutil.HTTPRequestHeader(hdr)...)
+	}
 	t.propagators.Inject(ctx, propagation.HeaderCarrier(r.Header))
 
+	recordMetrics := decision&NoMetrics == 0
+	metricAttrs := semconvutil.HTTPClientRequestMetrics(r)
+
 	res, err := t.rt.RoundTrip(r)
+	// The base RoundTripper may still be sending the request body, only what
+	// was sent so far is recorded.
+	if bw.capture != nil {
+		bw.capture.addEvent(span, "request.body", RequestBodyKey)
+	}
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		span.End()
+		if recordMetrics {
+			t.recordMetrics(ctx, requestStartTime, metricAttrs, bw.read.Load(), 0)
+		}
 		return res, err
 	}
 
+	if res.StatusCode > 0 {
+		metricAttrs = append(metricAttrs, semconv.HTTPStatusCode(res.StatusCode))
+	}
+	var onEnd func(read int64)
+	if recordMetrics {
+		onEnd = func(read int64) {
+			t.recordMetrics(ctx, requestStartTime, metricAttrs, bw.read.Load(), read)
+		}
+	}
+
 	span.SetAttributes(semconvutil.HTTPClientResponse(res)...)
+	if hdr := capturedHeaders(res.Header, t.responseHeaders, t.headerRedactor); len(hdr) > 0 {
+		span.SetAttributes(semconvutil.HTTPResponseHeader(hdr)...)
+	}
 	span.SetStatus(semconvutil.HTTPClientStatus(res.StatusCode))
-	res.Body = newWrappedBody(span, res.Body)
+	capture := newBodyCapture(t.bodyCaptureLimit, t.bodyCaptureTypes, res.Header.Get("Content-Type"))
+	res.Body = newWrappedBody(span, onEnd, capture, res.Body)
 
 	return res, err
 }
 
+// recordMetrics records the duration of the request started at start, along
+// with its request and response body sizes, even if the request was canceled.
+func (t *Transport) recordMetrics(ctx context.Context, start time.Time, attrs []attribute.KeyValue, requestSize, responseSize int64) {
+	ctx, o := withoutCancel(ctx), metric.WithAttributes(attrs...)
+	t.requestSize.Record(ctx, requestSize, o)
+	t.responseSize.Record(ctx, responseSize, o)
+
+	// Use floating point division here for higher precision (instead of Millisecond method).
+	elapsedTime := float64(time.Since(start)) / float64(time.Millisecond)
+
+	t.latency.Record(ctx, elapsedTime, o)
+}
+
 // newWrappedBody returns a new and appropriately scoped *wrappedBody as an
 // io.ReadCloser. If the passed body implements io.Writer, the returned value
 // will implement io.ReadWriteCloser.
-func newWrappedBody(span trace.Span, body io.ReadCloser) io.ReadCloser {
+//
+// If onEnd is not nil, it is called with the number of bytes read from body
+// once the span is ended. If capture is not nil, the start of body is added to
+// the span as an event before it is ended.
+func newWrappedBody(span trace.Span, onEnd func(read int64), capture *bodyCapture, body io.ReadCloser) io.ReadCloser {
 	// The successful protocol switch responses will have a body that
 	// implement an io.ReadWriteCloser. Ensure this interface type continues
 	// to be satisfied if that is the case.
 	if _, ok := body.(io.ReadWriteCloser); ok {
-		return &wrappedBody{span: span, body: body}
+		return &wrappedBody{span: span, onEnd: onEnd, capture: capture, body: body}
 	}
 
 	// Remove the implementation of the io.ReadWriteCloser and only implement
 	// the io.ReadCloser.
-	return struct{ io.ReadCloser }{&wrappedBody{span: span, body: body}}
+	return struct{ io.ReadCloser }{&wrappedBody{span: span, onEnd: onEnd, capture: capture, body: body}}
 }
 
 // wrappedBody is the response body type returned by the transport
 // instrumentation to complete a span. Errors encountered when using the
 // response body are recorded in span tracking the response.
 //
-// The span tracking the response is ended when this body is closed.
+// The span tracking the response is ended when this body is closed or
+// when a read from it returns io.EOF, whichever happens first.
 //
 // If the response body implements the io.Writer interface (i.e. for
 // successful protocol switches), the wrapped body also will.
 type wrappedBody struct {
-	span trace.Span
-	body io.ReadCloser
+	span    trace.Span
+	onEnd   func(read int64)
+	capture *bodyCapture
+	body    io.ReadCloser
+
+	read atomic.Int64
+	once sync.Once
 }
 
 var _ io.ReadWriteCloser = &wrappedBody{}
@@ -171,12 +297,16 @@ func (wb *wrappedBody) Write(p []byte) (int, error) {
 
 func (wb *wrappedBody) Read(b []byte) (int, error) {
 	n, err := wb.body.Read(b)
+	wb.read.Add(int64(n))
+	if wb.capture != nil {
+		wb.capture.write(b[:n])
+	}
 
 	switch err {
 	case nil:
 		// nothing to do here but fall through to the return
 	case io.EOF:
-		wb.span.End()
+		wb.end()
 	default:
 		wb.span.RecordError(err)
 		wb.span.SetStatus(codes.Error, err.Error())
@@ -185,9 +315,23 @@ func (wb *wrappedBody) Read(b []byte) (int, error) {
 }
 
 func (wb *wrappedBody) Close() error {
-	wb.span.End()
+	wb.end()
 	if wb.body != nil {
 		return wb.body.Close()
 	}
 	return nil
 }
+
+// end ends the span and reports the number of bytes read. It is safe to call
+// multiple times, only the first call has any effect.
+func (wb *wrappedBody) end() {
+	wb.once.Do(func() {
+		if wb.capture != nil {
+			wb.capture.addEvent(wb.span, "response.body", ResponseBodyKey)
+		}
+		wb.span.End()
+		if wb.onEnd != nil {
+			wb.onEnd(wb.read.Load())
+		}
+	})
+}
@@ -282,7 +282,7 @@ func TestWrappedBodyClose(t *testing.T) {
 func TestWrappedBodyClosePanic(t *testing.T) {
 	s := new(span)
 	var body io.ReadCloser
-	wb := newWrappedBody(s, body)
+	wb := newWrappedBody(s, nil, nil, body)
 	assert.NotPanics(t, func() { wb.Close() }, "nil body should not panic on close")
 }
 
@@ -294,6 +294,25 @@ func TestWrappedBodyCloseError(t *testing.T) {
 	s.assert(t, true, nil, codes.Unset, "")
 }
 
+func TestWrappedBodyOnEnd(t *testing.T) {
+	s := new(span)
+	var calls int
+	var got int64
+	onEnd := func(read int64) {
+		calls++
+		got = read
+	}
+	wb := newWrappedBody(s, onEnd, nil, readCloser{readErr: io.EOF})
+
+	_, err := wb.Read([]byte{})
+	assert.Equal(t, io.EOF, err)
+	assert.NoError(t, wb.Close())
+
+	s.assert(t, true, nil, codes.Unset, "")
+	assert.Equal(t, 1, calls, "onEnd should be called exactly once")
+	assert.Equal(t, int64(readSize), got, "wrong number of bytes read reported")
+}
+
 type readWriteCloser struct {
 	readCloser
 
@@ -307,12 +326,12 @@ func (rwc readWriteCloser) Write([]byte) (int, error) {
 }
 
 func TestNewWrappedBodyReadWriteCloserImplementation(t *testing.T) {
-	wb := newWrappedBody(nil, readWriteCloser{})
+	wb := newWrappedBody(nil, nil, nil, readWriteCloser{})
 	assert.Implements(t, (*io.ReadWriteCloser)(nil), wb)
 }
 
 func TestNewWrappedBodyReadCloserImplementation(t *testing.T) {
-	wb := newWrappedBody(nil, readCloser{})
+	wb := newWrappedBody(nil, nil, nil, readCloser{})
 	assert.Implements(t, (*io.ReadCloser)(nil), wb)
 
 	_, ok := wb.(io.ReadWriteCloser)
@@ -323,7 +342,7 @@ func TestWrappedBodyWrite(t *testing.T) {
 	s := new(span)
 	var rwc io.ReadWriteCloser
 	assert.NotPanics(t, func() {
-		rwc = newWrappedBody(s, readWriteCloser{}).(io.ReadWriteCloser)
+		rwc = newWrappedBody(s, nil, nil, readWriteCloser{}).(io.ReadWriteCloser)
 	})
 
 	n, err := rwc.Write([]byte{})
@@ -337,7 +356,7 @@ func TestWrappedBodyWriteError(t *testing.T) {
 	expectedErr := errors.New("test")
 	var rwc io.ReadWriteCloser
 	assert.NotPanics(t, func() {
-		rwc = newWrappedBody(s, readWriteCloser{
+		rwc = newWrappedBody(s, nil, nil, readWriteCloser{
 			writeErr: expectedErr,
 		}).(io.ReadWriteCloser)
 	})
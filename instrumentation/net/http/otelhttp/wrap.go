@@ -17,9 +17,15 @@ package otelhttp // import "go.opentelemetry.io/contrib/instrumentation/net/http
 import (
 	"context"
 	"io"
+	"mime"
 	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var _ io.ReadCloser = &bodyWrapper{}
@@ -30,16 +36,24 @@ type bodyWrapper struct {
 	io.ReadCloser
 	record func(n int64) // must not be nil
 
-	read int64
+	// read is accessed atomically as the body of an outgoing request may be
+	// read by the base RoundTripper concurrently with the response handling.
+	read atomic.Int64
 	err  error
+
+	// capture keeps the start of the body if it is not nil.
+	capture *bodyCapture
 }
 
 func (w *bodyWrapper) Read(b []byte) (int, error) {
 	n, err := w.ReadCloser.Read(b)
 	n1 := int64(n)
-	w.read += n1
+	w.read.Add(n1)
 	w.err = err
 	w.record(n1)
+	if w.capture != nil {
+		w.capture.write(b[:n])
+	}
 	return n, err
 }
 
@@ -67,6 +81,12 @@ type respWriterWrapper struct {
 	statusCode  int
 	err         error
 	wroteHeader bool
+
+	// newCapture returns the capture of the body with the given content type,
+	// or nil if it is not captured. It is called once, by the first Write of
+	// a part of the body.
+	newCapture func(contentType string) *bodyCapture
+	capture    *bodyCapture
 }
 
 func (w *respWriterWrapper) Header() http.Header {
@@ -77,9 +97,22 @@ func (w *respWriterWrapper) Write(p []byte) (int, error) {
 	if !w.wroteHeader {
 		w.WriteHeader(http.StatusOK)
 	}
+	if w.newCapture != nil && len(p) > 0 {
+		// Like net/http, the content type is detected from the start of the
+		// body if the handler did not set it.
+		contentType := w.Header().Get("Content-Type")
+		if contentType == "" {
+			contentType = http.DetectContentType(p)
+		}
+		w.capture = w.newCapture(contentType)
+		w.newCapture = nil
+	}
 	n, err := w.ResponseWriter.Write(p)
 	n1 := int64(n)
 	w.record(n1)
+	if w.capture != nil {
+		w.capture.write(p[:n])
+	}
 	w.written += n1
 	w.err = err
 	return n, err
@@ -97,3 +130,65 @@ func (w *respWriterWrapper) WriteHeader(statusCode int) {
 	}
 	w.ResponseWriter.WriteHeader(statusCode)
 }
+
+// bodyCapture keeps a copy of the start of a body, up to its limit.
+type bodyCapture struct {
+	limit int
+
+	// mu guards the fields below as the body of an outgoing request may be
+	// read by the base RoundTripper concurrently with the response handling.
+	mu        sync.Mutex
+	buf       []byte
+	truncated bool
+}
+
+// newBodyCapture returns a bodyCapture keeping up to limit bytes of a body
+// with contentType, or nil if limit is not positive or the media type of
+// contentType is not one of mediaTypes. All media types are captured if
+// mediaTypes is empty.
+func newBodyCapture(limit int, mediaTypes []string, contentType string) *bodyCapture {
+	if limit <= 0 {
+		return nil
+	}
+	if len(mediaTypes) > 0 {
+		mt, _, err := mime.ParseMediaType(contentType)
+		if err != nil || !matchMediaType(mediaTypes, mt) {
+			return nil
+		}
+	}
+	return &bodyCapture{limit: limit}
+}
+
+// matchMediaType returns whether mt is one of mediaTypes. A media type in
+// mediaTypes ending in "/*" matches all of its subtypes.
+func matchMediaType(mediaTypes []string, mt string) bool {
+	for _, t := range mediaTypes {
+		if t == mt || (strings.HasSuffix(t, "/*") && strings.HasPrefix(mt, t[:len(t)-1])) {
+			return true
+		}
+	}
+	return false
+}
+
+// write copies p to the captured body up to the limit.
+func (c *bodyCapture) write(p []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if n := c.limit - len(c.buf); len(p) > n {
+		p = p[:n]
+		c.truncated = true
+	}
+	c.buf = append(c.buf, p...)
+}
+
+// addEvent adds an event with the captured body as the value of key to span.
+func (c *bodyCapture) addEvent(span trace.Span, name string, key attribute.Key) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	span.AddEvent(name, trace.WithAttributes(
+		key.String(string(c.buf)),
+		BodyTruncatedKey.Bool(c.truncated),
+	))
+}